@@ -1,4 +1,4 @@
-package main
+package day1
 
 // SumConsecutiveIntegers returns the sum of consecutive integers in a circular array
 func SumConsecutiveIntegers(data []int) int {
@@ -1,29 +1,48 @@
-package main
+package day1
 
 import (
-	"fmt"
-	"io/ioutil"
+	"io"
 	"strconv"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	input, err := ioutil.ReadFile("input.txt")
+func init() {
+	puzzle.Register(2017, 1, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	digits []int
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	digits, err := parseDigits(input)
+	s.digits = digits
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return SumConsecutiveIntegers(s.digits), nil
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return SumOppositeIntegers(s.digits), nil
+}
+
+func parseDigits(input io.Reader) ([]int, error) {
+	raw, err := io.ReadAll(input)
 	if err != nil {
-		panic("Cannot read input")
+		return nil, err
 	}
-	str := strings.TrimSpace(string(input))
+	str := strings.TrimSpace(string(raw))
 	chars := strings.Split(str, "")
 	integers := make([]int, len(chars))
 	for pos, str := range chars {
 		integers[pos], err = strconv.Atoi(str)
 		if err != nil {
-			panic(err.Error)
+			return nil, err
 		}
 	}
-	consecutiveSum := SumConsecutiveIntegers(integers)
-	oppositeSum := SumOppositeIntegers(integers)
-
-	fmt.Printf("Consecutive numbers result: %d\n", consecutiveSum)
-	fmt.Printf("Opposite numbers result: %d\n", oppositeSum)
+	return integers, nil
 }
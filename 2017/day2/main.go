@@ -1,42 +1,47 @@
-package main
+package day2
 
 import (
 	"bufio"
-	"fmt"
 	"io"
-	"log"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	file, err := os.Open("./input.txt")
-	if err != nil {
-		log.Fatal(err)
+func init() {
+	puzzle.Register(2017, 2, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	rows [][]int
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	scanner := NewScanner(input)
+	for scanner.Scan() {
+		s.rows = append(s.rows, scanner.Values())
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	return scanner.Err()
+}
 
-	s := NewScanner(file)
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return checksumRows(s.rows, DiffChecksum).Value(), nil
+}
 
-	diffChecksum := NewChecksum()
-	modChecksum := NewChecksum()
-	modChecksum.Checksum(ModulusChecksum)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return checksumRows(s.rows, ModulusChecksum).Value(), nil
+}
 
-	for s.Scan() {
-		row := s.Values()
-		diffChecksum.Add(row)
-		modChecksum.Add(row)
-	}
+func checksumRows(rows [][]int, strategy ChecksumFunc) *Checksum {
+	checksum := NewChecksum()
+	checksum.Checksum(strategy)
 
-	if err := s.scanner.Err(); err != nil {
-		log.Fatal(err)
+	for _, row := range rows {
+		checksum.Add(row)
 	}
 
-	fmt.Printf("Diff Checksum: %d\n", diffChecksum.Value())
-	fmt.Printf("Modulus Checksum: %d\n", modChecksum.Value())
+	return checksum
 }
 
 // IntScanner decorates a Scanner and returns integer slices as output
@@ -1,18 +1,45 @@
-package main
+package day3
 
 import (
 	"fmt"
+	"io"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
+func init() {
+	puzzle.Register(2017, 3, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	square int
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	square, err := parseSquare(input)
+	s.square = square
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
 	spiral := SpiralGrid{}
-	input := 277678
-	manhattanDistance := spiral.ManhattanDistance(input)
-	fmt.Printf("Manhattan distance for %d: %d\n", input, manhattanDistance)
+	return spiral.ManhattanDistance(s.square), nil
+}
 
+func (s *solver) Part2() (puzzle.Answer, error) {
+	spiral := SpiralGrid{}
 	var cumulativeSum int
-	for i := 1; cumulativeSum <= input; i++ {
+	for i := 1; cumulativeSum <= s.square; i++ {
 		cumulativeSum = spiral.CumulativeSumToPosition(i)
 	}
-	fmt.Printf("Cumulative spiral distance for %d: %d\n", input, cumulativeSum)
+	return cumulativeSum, nil
+}
+
+// parseSquare reads the puzzle input, which is a single square number on the spiral
+func parseSquare(input io.Reader) (int, error) {
+	var square int
+	if _, err := fmt.Fscan(input, &square); err != nil {
+		return 0, fmt.Errorf("reading square number: %w", err)
+	}
+	return square, nil
 }
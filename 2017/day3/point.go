@@ -1,6 +0,0 @@
-package main
-
-type point struct {
-	x int
-	y int
-}
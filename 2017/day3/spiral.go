@@ -1,27 +1,22 @@
-package main
+package day3
 
 import (
 	"fmt"
 	"math"
+
+	"github.com/neilfenwick/advent-of-code/geometry"
 )
 
+type point = geometry.Point[int]
+
 // SpiralGrid represents a set of integers that are mapped around
-// the center of a cartesian plane, spiraling outwards in an
-// anti-clockwise direction from the positive x-axis
+// the centre of a plane, spiraling outwards in an anti-clockwise
+// direction, starting East of the centre
 type SpiralGrid struct {
 	grid        map[int]point
 	pointValues map[point]int
 }
 
-type direction int
-
-const (
-	up direction = iota * 90
-	right
-	down
-	left
-)
-
 // ManhattanDistance returns the sum of the x and y components
 // of a diagonal, from the value to the centre of a spiral grid
 func (s *SpiralGrid) ManhattanDistance(val int) int {
@@ -96,12 +91,12 @@ func (s *SpiralGrid) generateGridToPoint(val int) {
 		s.grid = make(map[int]point, val)
 	}
 
-	var dir direction
+	var dir geometry.Direction
 
 	// start at the head of the grid and step
 	for pos := len(s.grid) + 1; pos <= val; pos++ {
 		if pos == 1 {
-			s.grid[1] = point{0, 0}
+			s.grid[1] = point{}
 			continue
 		}
 
@@ -119,17 +114,17 @@ func (s *SpiralGrid) generateGridToPoint(val int) {
 		}
 
 		if pos == innerRingMax+1 {
-			dir = right
+			dir = geometry.East
 		} else {
-			dir = up
+			dir = geometry.North
 			nextCorner := innerRingMax + ring
 			for nextCorner < pos {
 				nextCorner += ring - 1
-				dir = (dir + 270) % 360
+				dir = dir.TurnLeft()
 			}
 			for i := innerRingMax + 1; i <= pos; i++ {
 				if i == nextCorner {
-					dir = (dir + 270) % 360
+					dir = dir.TurnLeft()
 				}
 			}
 		}
@@ -139,33 +134,22 @@ func (s *SpiralGrid) generateGridToPoint(val int) {
 	}
 }
 
-func nextPnt(pnt point, dir direction) point {
-	if pnt.x == 0 && pnt.y == 0 {
-		return point{1, 0}
-	}
-	switch dir {
-	case up:
-		return point{pnt.x, pnt.y + 1}
-	case left:
-		return point{pnt.x - 1, pnt.y}
-	case down:
-		return point{pnt.x, pnt.y - 1}
-	case right:
-		return point{pnt.x + 1, pnt.y}
+func nextPnt(pnt point, dir geometry.Direction) point {
+	if pnt == (point{}) {
+		return pnt.Move(geometry.East)
 	}
-	panic(fmt.Sprintf("Expected direction 0, 90, 180, 270. Got %d.", dir))
+	return pnt.Move(dir)
 }
 
 // neighboursFor finds all poIntegers whose position occurs earlier
-// in the spiral and whose pythagorean distance is <= sqrt(2) away
-// from the point at the location of the parameter value
+// in the spiral and that are next to the point at the location of
+// the parameter value, including diagonally
 func (s *SpiralGrid) neighboursFor(val int) map[int]point {
 	referencePoint := s.grid[val]
 	result := make(map[int]point)
 	for i := 1; i < val; i++ {
 		pnt := s.grid[i]
-		dist := math.Sqrt(math.Pow(float64(referencePoint.x-pnt.x), 2) + math.Pow(float64(referencePoint.y-pnt.y), 2))
-		if dist <= math.Sqrt(2) {
+		if referencePoint.Chebyshev(pnt) <= 1 {
 			result[i] = pnt
 		}
 	}
@@ -1,37 +1,44 @@
-package main
+package day4
 
 import (
 	"bufio"
-	"fmt"
-	"os"
+	"io"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	file, err := os.Open("./input.txt")
-	if err != nil {
-		panic("Could not read input file")
+func init() {
+	puzzle.Register(2017, 4, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	passPhrases []string
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	scanner := bufio.NewScanner(input)
+	for scanner.Scan() {
+		s.passPhrases = append(s.passPhrases, scanner.Text())
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	return scanner.Err()
+}
 
-	wordsValidator := NewPassValidator()
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return countValidPhrases(s.passPhrases, NewPassValidator()), nil
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
 	anagramsValidator := NewPassValidator()
 	anagramsValidator.EntropyFunc(IsAnagramsInPhrase)
+	return countValidPhrases(s.passPhrases, anagramsValidator), nil
+}
 
-	numValidUniques, numValidAnagrams := 0, 0
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		passPhrase := scanner.Text()
-		if wordsValidator.IsValid(passPhrase) {
-			numValidUniques++
-		}
-
-		if anagramsValidator.IsValid(passPhrase) {
-			numValidAnagrams++
+func countValidPhrases(passPhrases []string, validator PassValidator) int {
+	numValid := 0
+	for _, passPhrase := range passPhrases {
+		if validator.IsValid(passPhrase) {
+			numValid++
 		}
 	}
-
-	fmt.Printf("Number of valid phrases with unique words: %d\n", numValidUniques)
-	fmt.Printf("Number of valid phrases with unique anagrams: %d\n", numValidAnagrams)
+	return numValid
 }
@@ -1,4 +1,4 @@
-package main
+package day5
 
 // List maintains the state for a slice of values
 // that are used to calculate jump-list offsets
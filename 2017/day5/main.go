@@ -1,37 +1,49 @@
-package main
+package day5
 
 import (
 	"bufio"
 	"fmt"
-	"os"
+	"io"
 	"strconv"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	file, err := os.Open("./input.txt")
-	if err != nil {
-		panic("Could not read input.txt in current directory")
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func init() {
+	puzzle.Register(2017, 5, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	offsets []int
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	offsets, err := parseOffsets(input)
+	s.offsets = offsets
+	return err
+}
 
+func (s *solver) Part1() (puzzle.Answer, error) {
+	jumpList := NewList(s.offsets)
+	return jumpList.CalcJumps(), nil
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
+	jumpListNewStrategy := NewList(s.offsets)
+	jumpListNewStrategy.OffsetCalcFunc(NewStrategyOffsetCalc)
+	return jumpListNewStrategy.CalcJumps(), nil
+}
+
+func parseOffsets(input io.Reader) ([]int, error) {
 	values := make([]int, 0)
 
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(input)
 	for scanner.Scan() {
-		line := scanner.Text()
-		num, err := strconv.Atoi(line)
+		num, err := strconv.Atoi(scanner.Text())
 		if err != nil {
-			panic("Error converting value in input to an integer")
+			return nil, fmt.Errorf("converting value in input to an integer: %w", err)
 		}
 		values = append(values, num)
 	}
-
-	jumpList := NewList(values)
-	jumpListNewStrategy := NewList(values)
-	jumpListNewStrategy.OffsetCalcFunc(NewStrategyOffsetCalc)
-
-	fmt.Printf("Took %d jumps to exit the list\n", jumpList.CalcJumps())
-	fmt.Printf("New Strategy took %d jumps to exit the list\n", jumpListNewStrategy.CalcJumps())
+	return values, scanner.Err()
 }
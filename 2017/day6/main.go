@@ -1,16 +1,48 @@
-package main
+package day6
 
 import (
-	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	input := []int{0, 5, 10, 0, 11, 14, 13, 4, 11, 8, 8, 7, 1, 4, 12, 11}
-	c := make([]int, len(input))
-	copy(c, input)
-	result := Rebalance(input)
-	fmt.Printf("Rebalanced %v in %d iterations.\n Final state %v\n", c, result, input)
+func init() {
+	puzzle.Register(2017, 6, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	memory []int
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	memory, err := parseMemoryBanks(input)
+	s.memory = memory
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return Rebalance(s.memory), nil
+}
+
+// Part2 returns the number of iterations in the loop of repeated states
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return findLoop(s.memory).Length, nil
+}
+
+func parseMemoryBanks(input io.Reader) ([]int, error) {
+	raw, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
 
-	loopCycle := Rebalance(input)
-	fmt.Printf("Inifnite loop cycle is %d iterations.\n", loopCycle)
+	fields := strings.Fields(string(raw))
+	memory := make([]int, len(fields))
+	for i, field := range fields {
+		if memory[i], err = strconv.Atoi(field); err != nil {
+			return nil, err
+		}
+	}
+	return memory, nil
 }
@@ -1,44 +1,45 @@
-package main
+package day6
 
 import (
-	"crypto/md5"
-	"encoding/binary"
-	"math"
+	"fmt"
+
+	"github.com/neilfenwick/advent-of-code/cycle"
 )
 
 // Rebalance iterates over the input and distributes
 // the values evenly amongst each of the slots in the
 // input slice, returning the number of iterations required
-// to rebalance
+// before a state is repeated
 func Rebalance(memory []int) int {
+	loop := findLoop(memory)
+	return loop.Start + loop.Length
+}
+
+// findLoop finds when rebalancing the memory starts to repeat the same states
+func findLoop(memory []int) cycle.Cycle {
 	if len(memory) < 2 {
-		return 0
+		return cycle.Cycle{}
 	}
+	return cycle.DetectFunc(memory, redistribute, func(banks []int) string { return fmt.Sprint(banks) }).Cycle
+}
 
-	iterations := 0
-	hashes := make(map[int]bool)
-
-	for {
-		hsh := hash(memory)
-		if _, exists := hashes[hsh]; exists {
-			break
+// redistribute empties the fullest bank and shares its blocks out one at a time
+// to the banks after it, returning the new state of the memory
+func redistribute(memory []int) []int {
+	result := make([]int, len(memory))
+	copy(result, memory)
+
+	pos, max := maxVal(result)
+	result[pos] = 0
+	for i := max; i > 0; i-- {
+		pos = pos + 1
+		if pos >= len(result) {
+			pos = 0
 		}
 
-		hashes[hsh] = false
-		iterations++
-		pos, max := maxVal(memory)
-		memory[pos] = 0
-		for i := max; i > 0; i-- {
-			pos = pos + 1
-			if pos >= len(memory) {
-				pos = 0
-			}
-
-			val := memory[pos]
-			memory[pos] = val + 1
-		}
+		result[pos]++
 	}
-	return iterations
+	return result
 }
 
 func maxVal(values []int) (int, int) {
@@ -52,16 +53,3 @@ func maxVal(values []int) (int, int) {
 
 	return pos, val
 }
-
-func hash(values []int) int {
-	hash := md5.New()
-	for pos, val := range values {
-		b := make([]byte, 8)
-		binary.LittleEndian.PutUint64(b, uint64(math.Pow(float64(pos), 2)))
-		binary.LittleEndian.PutUint64(b, uint64(val))
-		hash.Write(b)
-	}
-
-	result := hash.Sum(nil)
-	return int(binary.LittleEndian.Uint64(result))
-}
@@ -1,140 +1,134 @@
-package main
+package day7
 
 import (
 	"bufio"
 	"fmt"
-	"os"
-	"sort"
+	"io"
 
 	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	file, err := os.Open("./input.txt")
-	if err != nil {
-		panic("Could not open input.txt")
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func init() {
+	puzzle.Register(2017, 7, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	root    *data.GenericTreeNode[Disc]
+	nodeMap map[string]*data.GenericTreeNode[Disc]
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	root, nodeMap, err := buildDiscTree(input)
+	s.root, s.nodeMap = root, nodeMap
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return s.root.Value.Name, nil
+}
+
+// Part2 returns the weight the unbalanced program would need to balance the tower
+func (s *solver) Part2() (puzzle.Answer, error) {
+	unbalancedName, weightDiff := GetUnbalanced(s.root)
+	unbalancedDisc := s.nodeMap[unbalancedName].Value
+	return unbalancedDisc.Weight - weightDiff, nil
+}
 
-	scanner := bufio.NewScanner(file)
+// buildDiscTree returns the bottom disc of the tower, along with an index of every
+// disc in the tower by name
+func buildDiscTree(input io.Reader) (*data.GenericTreeNode[Disc], map[string]*data.GenericTreeNode[Disc], error) {
+	scanner := bufio.NewScanner(input)
 	discs := make(map[string]Disc, 0)
 	for scanner.Scan() {
 		text := scanner.Text()
 		d := ParseDisc(text)
 		discs[d.Name] = d
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(discs) == 0 {
+		return nil, nil, fmt.Errorf("no discs found in input")
+	}
 
-	var t *data.GenericTree[Disc]
-	nodeMap := make(map[string]*data.GenericTreeNode[Disc])
-	for _, disc := range discs {
-		var node *data.GenericTreeNode[Disc]
-		if t == nil {
-			t = data.NewGenericTree(disc)
-			node = t.Root
-		}
-		if n, ok := nodeMap[disc.Name]; ok {
-			node = n
-		} else if node == nil {
-			node = &data.GenericTreeNode[Disc]{Value: disc}
-			nodeMap[disc.Name] = node
-		}
-		nodeMap[disc.Name] = node
-		for _, childName := range disc.Children {
-			child := discs[childName]
-			childNode := nodeMap[child.Name]
-			if childNode == nil {
-				childNode = node.AddChild(child)
-				nodeMap[child.Name] = childNode
-			} else {
-				childNode.Parent = node
-				node.Children = append(node.Children, childNode)
+	nodeMap := make(map[string]*data.GenericTreeNode[Disc], len(discs))
+	for name, disc := range discs {
+		nodeMap[name] = &data.GenericTreeNode[Disc]{Value: disc}
+	}
+	for _, node := range nodeMap {
+		for _, childName := range node.Value.Children {
+			child, found := nodeMap[childName]
+			if !found {
+				return nil, nil, fmt.Errorf("disc %s holds unknown disc %s", node.Value.Name, childName)
+			}
+			if err := child.Reparent(node); err != nil {
+				return nil, nil, fmt.Errorf("disc %s holds disc %s: %w", node.Value.Name, childName, err)
 			}
 		}
 	}
 
-	root := t.Root
+	var root *data.GenericTreeNode[Disc]
+	for _, node := range nodeMap {
+		root = node
+		break
+	}
 	for root.Parent != nil {
 		root = root.Parent
 	}
-	name := root.Value.Name
-	unbalancedName, weightDiff := GetUnbalanced(root)
-	unbalancedNode := nodeMap[unbalancedName]
-	unbalancedDisc := unbalancedNode.Value
-
-	fmt.Printf("The bottom program is called: %s\n", name)
-	fmt.Printf("Program '%+v' is unbalanced. Its weight is %d away from what it should be.\n", unbalancedDisc, weightDiff)
+	return root, nodeMap, nil
 }
 
-// GetUnbalanced recursively searches down the tree (depth-first), following branches that do not
-// have Discs with a total weight equal to their siblings.
-// Returns the name of the lowest-level unbalanced Disc, with the difference in its weight
+// GetUnbalanced searches up the tower from the bottom, following the disc whose total weight
+// differs from its siblings.
+// Returns the name of the highest unbalanced Disc, with the difference in its weight
 func GetUnbalanced(root *data.GenericTreeNode[Disc]) (string, int) {
-	name, weightDelta := searchForUnbalancedChildren(root)
-	return name, weightDelta
-}
+	weights := towerWeights(root)
 
-func searchForUnbalancedChildren(startNode *data.GenericTreeNode[Disc]) (string, int) {
-	type nodeWeights struct {
-		names  []string
-		weight int
+	name, weightDelta := root.Value.Name, 0
+	for node := root; ; {
+		odd, delta, found := oddOneOut(node.Children, weights)
+		if !found {
+			return name, weightDelta
+		}
+		node, name, weightDelta = odd, odd.Value.Name, delta
 	}
+}
 
-	children := startNode.Children
-	var weights []nodeWeights
-	weightMap := make(map[int]int)
+// towerWeights totals the weight of each disc along with every disc it holds up
+func towerWeights(root *data.GenericTreeNode[Disc]) map[*data.GenericTreeNode[Disc]]int {
+	weights := make(map[*data.GenericTreeNode[Disc]]int)
+	data.Fold(root, func(node *data.GenericTreeNode[Disc], children []int) int {
+		weight := node.Value.Weight
+		for _, childWeight := range children {
+			weight += childWeight
+		}
+		weights[node] = weight
+		return weight
+	})
+	return weights
+}
 
+// oddOneOut finds the child whose total weight differs from the others, and how much heavier it is
+func oddOneOut(children []*data.GenericTreeNode[Disc], weights map[*data.GenericTreeNode[Disc]]int) (*data.GenericTreeNode[Disc], int, bool) {
+	counts := make(map[int]int, 2)
 	for _, child := range children {
-		weight := getNodeWeight(child)
-		if pos, ok := weightMap[weight]; ok {
-			nodeWeight := weights[pos]
-			nodeWeight.names = append(nodeWeight.names, child.Value.Name)
-			weights[pos] = nodeWeight
-		} else {
-			var names []string
-			names = append(names, child.Value.Name)
-			nodeWeight := nodeWeights{names: names, weight: weight}
-			weights = append(weights, nodeWeight)
-			weightMap[weight] = len(weights) - 1
-		}
+		counts[weights[child]]++
 	}
-
-	// all weights equal, exit
-	if len(weights) == 1 {
-		return startNode.Value.Name, 0
+	if len(counts) < 2 {
+		return nil, 0, false
 	}
 
-	sort.Slice(weights, func(i, j int) bool {
-		return len(weights[i].names) < len(weights[j].names)
-	})
-
-	unbalancedName := weights[0].names[0]
-	unbalancedDelta := weights[0].weight - weights[1].weight
-
-	childNameSearch, childDelta := searchForUnbalancedChildren(findChildByName(children, unbalancedName))
-
-	if childDelta == 0 {
-		return unbalancedName, unbalancedDelta
+	var balancedWeight int
+	for weight, count := range counts {
+		if count > 1 {
+			balancedWeight = weight
+		}
 	}
-
-	return childNameSearch, childDelta
-}
-
-func findChildByName(children []*data.GenericTreeNode[Disc], name string) *data.GenericTreeNode[Disc] {
 	for _, child := range children {
-		if child.Value.Name == name {
-			return child
+		if counts[weights[child]] == 1 && weights[child] != balancedWeight {
+			return child, weights[child] - balancedWeight, true
 		}
 	}
-	return nil
-}
-
-func getNodeWeight(node *data.GenericTreeNode[Disc]) int {
-	var weight int
-	for _, child := range node.Children {
-		weight += getNodeWeight(child)
-	}
-	weight += node.Value.Weight
-	return weight
+	return nil, 0, false
 }
@@ -1,25 +1,49 @@
-package main
+package day8
 
 import (
 	"bufio"
 	"fmt"
-	"log"
-	"os"
+	"io"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	file, err := os.Open("./input.txt")
-	if err != nil {
-		log.Fatal("Unable to open input.txt file")
+func init() {
+	puzzle.Register(2017, 8, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	registerMap map[string]int
+	max         int
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	registerMap, max, err := executeInstructions(input)
+	s.registerMap, s.max = registerMap, max
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	largest := 0
+	for _, value := range s.registerMap {
+		if value > largest {
+			largest = value
+		}
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	return largest, nil
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return s.max, nil
+}
 
+// executeInstructions runs every instruction in the input, and returns the final
+// register values along with the highest value held in any register along the way
+func executeInstructions(input io.Reader) (map[string]int, int, error) {
 	registerMap := make(map[string]int)
 
 	max := 0
-	s := bufio.NewScanner(file)
+	s := bufio.NewScanner(input)
 	for s.Scan() {
 		var reg, ins, reg2, op string
 		var delta, referenceValue int
@@ -40,7 +64,7 @@ func main() {
 		}
 	}
 
-	fmt.Printf("%v\n\nMax register value: %d\n", registerMap, max)
+	return registerMap, max, s.Err()
 }
 
 type instruction struct {
@@ -1,49 +1,57 @@
-package main
+package day1
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
-	"os"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
 type fuelCalc func(int) int
 
-func main() {
-	start()
+func init() {
+	puzzle.Register(2019, 1, func() puzzle.Solver { return &solver{} })
 }
 
-func start() {
-	file, err := os.Open(os.Args[1])
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+type solver struct {
+	weights []int
+}
 
-	fuel := calculateRocketFuel(file, calculationFuelForWeight)
-	fmt.Printf("Part 1: %d\n", fuel)
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.weights, err = parseModuleWeights(input)
+	return err
+}
 
-	_, _ = file.Seek(0, 0)
-	fuel = calculateRocketFuel(file, calculateModuleInclusiveFuel)
-	fmt.Printf("Part 2: %d\n", fuel)
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return calculateRocketFuel(s.weights, calculationFuelForWeight), nil
 }
 
-func calculateRocketFuel(r io.Reader, fn fuelCalc) int {
-	var (
-		fuel int
-	)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return calculateRocketFuel(s.weights, calculateModuleInclusiveFuel), nil
+}
+
+func parseModuleWeights(r io.Reader) ([]int, error) {
+	var weights []int
 	s := bufio.NewScanner(r)
 	s.Split(bufio.ScanLines)
 	for s.Scan() {
 		var n int
 		_, err := fmt.Sscanf(s.Text(), "%d", &n)
 		if err != nil {
-			log.Fatalf("Could not read %s: %v", s.Text(), err)
+			return nil, fmt.Errorf("could not read %s: %w", s.Text(), err)
 		}
-		fuel += fn(n)
+		weights = append(weights, n)
+	}
+	return weights, s.Err()
+}
+
+func calculateRocketFuel(weights []int, fn fuelCalc) int {
+	var (
+		fuel int
+	)
+	for _, weight := range weights {
+		fuel += fn(weight)
 	}
 	return fuel
 }
@@ -1,10 +1,12 @@
-package main
+package day1
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
-	"log"
-	"os"
+	"io"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
 type pair struct {
@@ -12,41 +14,54 @@ type pair struct {
 	item2 int
 }
 
-func main() {
-	f, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal("Could not read input data")
+func init() {
+	puzzle.Register(2020, 1, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	expenses []int
+	target   int
+}
+
+func (s *solver) Params(params *flag.FlagSet) {
+	params.IntVar(&s.target, "target", 2020, "sum the expense entries must add up to")
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.expenses, err = parseExpenses(input)
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	pair := findFirstPairTargetSum(s.expenses, s.target)
+	return pair.item1 * pair.item2, nil
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
+	triple := findFirstNItemsTargetSum(s.expenses, 3, s.target)
+
+	agg := 1
+	for _, v := range triple {
+		agg = agg * v
 	}
-	defer func(f *os.File) {
-		_ = f.Close()
-	}(f)
+	return agg, nil
+}
 
+func parseExpenses(input io.Reader) ([]int, error) {
 	data := make([]int, 0, 10)
 
-	s := bufio.NewScanner(f)
+	s := bufio.NewScanner(input)
 
 	for s.Scan() {
 		var n int
 		_, err := fmt.Sscanf(s.Text(), "%d", &n)
 		if err != nil {
-			log.Fatalf("Could not read %s: %v", s.Text(), err)
+			return nil, fmt.Errorf("could not read %s: %w", s.Text(), err)
 		}
 		data = append(data, n)
 	}
 
-	pair := findFirstPairTargetSum(data, 2020)
-
-	fmt.Printf("Pair: %v\n", pair)
-	fmt.Printf("Pair Result: %d\n", pair.item1*pair.item2)
-
-	triple := findFirstNItemsTargetSum(data, 3, 2020)
-
-	agg := 1
-	for _, v := range triple {
-		agg = agg * v
-	}
-	fmt.Printf("Triple: %v\n", triple)
-	fmt.Printf("Triple Result: %d\n", agg)
+	return data, s.Err()
 }
 
 func findFirstPairTargetSum(data []int, target int) pair {
@@ -1,10 +1,11 @@
-package main
+package day2
 
 import (
 	"bufio"
 	"fmt"
-	"log"
-	"os"
+	"io"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
 type passwordDefinition struct {
@@ -14,14 +15,40 @@ type passwordDefinition struct {
 	clearText      string
 }
 
-func main() {
-	f, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatalf("Could not open input for reading: %v\n", err)
+func init() {
+	puzzle.Register(2020, 2, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	definitions []passwordDefinition
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	s.definitions = parsePasswordDefinitions(input)
+	return nil
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return s.countValid(testPasswordMinOccurrences), nil
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return s.countValid(testPasswordSpecificPosition), nil
+}
+
+func (s *solver) countValid(policy func(passwordDefinition) bool) int {
+	validPasswords := 0
+	for _, def := range s.definitions {
+		if policy(def) {
+			validPasswords++
+		}
 	}
+	return validPasswords
+}
 
+func parsePasswordDefinitions(input io.Reader) []passwordDefinition {
 	pwdDefinitions := make([]passwordDefinition, 0, 10)
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(input)
 	for scanner.Scan() {
 		var minOccurrences, maxOccurrences int
 		var requiredChar rune
@@ -30,24 +57,7 @@ func main() {
 		_, _ = fmt.Sscanf(scanner.Text(), "%d-%d %c: %s", &minOccurrences, &maxOccurrences, &requiredChar, &clearText)
 		pwdDefinitions = append(pwdDefinitions, passwordDefinition{minOccurrences: minOccurrences, maxOccurrences: maxOccurrences, requiredChar: requiredChar, clearText: clearText})
 	}
-
-	validPasswords := 0
-	for _, def := range pwdDefinitions {
-		if testPasswordMinOccurrences(def) {
-			validPasswords++
-		}
-	}
-
-	fmt.Printf("Valid password min-occurence count: %d\n", validPasswords)
-
-	validPasswords = 0
-	for _, def := range pwdDefinitions {
-		if testPasswordSpecificPosition(def) {
-			validPasswords++
-		}
-	}
-
-	fmt.Printf("Valid password specific-position count: %d\n", validPasswords)
+	return pwdDefinitions
 }
 
 func testPasswordMinOccurrences(def passwordDefinition) bool {
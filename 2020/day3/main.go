@@ -1,43 +1,49 @@
-package main
+package day3
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
+	"io"
+
+	"github.com/neilfenwick/advent-of-code/grid"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	f, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatalf("Could not open input for reading: %v\n", err)
-	}
+func init() {
+	puzzle.Register(2020, 3, func() puzzle.Solver { return &solver{} })
+}
 
-	rows := make([][]rune, 0, 50)
+type solver struct {
+	slope *grid.Grid[rune]
+}
 
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
-		rows = append(rows, []rune(scanner.Text()))
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.slope, err = grid.ParseRunes(input)
+	if err != nil {
+		return err
 	}
+	// the pattern repeats to the right as far as needed
+	s.slope.Wrap = true
+	return nil
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return countTreesAlongPath(s.slope, 3, 1), nil
+}
 
-	treeCount11 := countTreesAlongPath(rows, 1, 1)
-	treeCount31 := countTreesAlongPath(rows, 3, 1)
-	treeCount51 := countTreesAlongPath(rows, 5, 1)
-	treeCount71 := countTreesAlongPath(rows, 7, 1)
-	treeCount12 := countTreesAlongPath(rows, 1, 2)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	treeCount11 := countTreesAlongPath(s.slope, 1, 1)
+	treeCount31 := countTreesAlongPath(s.slope, 3, 1)
+	treeCount51 := countTreesAlongPath(s.slope, 5, 1)
+	treeCount71 := countTreesAlongPath(s.slope, 7, 1)
+	treeCount12 := countTreesAlongPath(s.slope, 1, 2)
 
-	fmt.Printf("Tree count 3*1: %d\n", treeCount31)
-	fmt.Printf("Tree count: %d\n", treeCount11*treeCount31*treeCount51*treeCount71*treeCount12)
+	return treeCount11 * treeCount31 * treeCount51 * treeCount71 * treeCount12, nil
 }
 
-func countTreesAlongPath(rows [][]rune, horizontalIncrement int, verticalIncrement int) int {
-	horizontalPos := 0
+func countTreesAlongPath(slope *grid.Grid[rune], horizontalIncrement int, verticalIncrement int) int {
 	treeCount := 0
-	for i := verticalIncrement; i < len(rows); i = i + verticalIncrement {
-		horizontalPos += horizontalIncrement
-		row := rows[i]
-		rowIndex := horizontalPos % len(row)
-		if '#' == row[rowIndex] {
+	step := grid.Vector{X: horizontalIncrement, Y: verticalIncrement}
+	for pos := (grid.Point{}).Add(step); pos.Y < slope.Height(); pos = pos.Add(step) {
+		if c, _ := slope.Get(pos); c == '#' {
 			treeCount++
 		}
 	}
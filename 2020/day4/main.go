@@ -1,14 +1,15 @@
-package main
+package day4
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
 type passport struct {
@@ -22,19 +23,35 @@ type passport struct {
 	cid string
 }
 
-func main() {
-	f, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatalf("Could not open input for reading: %v", err)
-	}
-	defer func(f *os.File) {
-		_ = f.Close()
-	}(f)
+func init() {
+	puzzle.Register(2020, 4, func() puzzle.Solver { return &solver{} })
+}
 
-	validCount, invalidCount := day4(f)
+type solver struct {
+	input []byte
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	completeCount := 0
+	scanner := bufio.NewScanner(bytes.NewReader(s.input))
+	scanner.Split(emptyLineSplitFunc)
+	for scanner.Scan() {
+		passport := strings.Replace(scanner.Text(), "\n", " ", -1)
+		if _, err := extractPassportFields(passport); err == nil {
+			completeCount++
+		}
+	}
+	return completeCount, nil
+}
 
-	fmt.Printf("Valid passports: %d\n", validCount)
-	fmt.Printf("Invalid passports: %d\n", invalidCount)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	validCount, _ := day4(bytes.NewReader(s.input))
+	return validCount, nil
 }
 
 func day4(reader io.Reader) (validCount int, invalidCount int) {
@@ -1,12 +1,14 @@
-package main
+package day5
 
 import (
 	"bufio"
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"regexp"
+	"slices"
 	"sort"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
 type seat struct {
@@ -15,44 +17,55 @@ type seat struct {
 	column int
 }
 
-func main() {
-	f, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatal("Could not open input for reading")
-	}
-	defer func(f *os.File) {
-		_ = f.Close()
-	}(f)
+func init() {
+	puzzle.Register(2020, 5, func() puzzle.Solver { return &solver{} })
+}
 
-	seats := make([]seat, 0, 10)
-	s := bufio.NewScanner(f)
-	for s.Scan() {
-		seat := newSeat(s.Text(), 128, 8)
-		seats = append(seats, seat)
+type solver struct {
+	seats []seat
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	s.seats = parseSeats(input)
+	return nil
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	maxSeatID := 0
+	for _, seat := range s.seats {
+		if seat.id > maxSeatID {
+			maxSeatID = seat.id
+		}
 	}
+	return maxSeatID, nil
+}
 
+func (s *solver) Part2() (puzzle.Answer, error) {
+	seats := slices.Clone(s.seats)
 	sort.Slice(seats, func(i, j int) bool {
 		return seats[i].id < seats[j].id
 	})
 
 	lastID := 0
 	missingSeatID := 0
-	maxSeatID := 0
 	for _, seat := range seats {
-		//fmt.Printf("Seat: %+v\n", seat)
 		if seat.id == lastID+2 {
 			missingSeatID = seat.id - 1
 		} else {
 			lastID = seat.id
 		}
-
-		if seat.id > maxSeatID {
-			maxSeatID = seat.id
-		}
 	}
+	return missingSeatID, nil
+}
 
-	fmt.Printf("Highest seat id: %d\n", maxSeatID)
-	fmt.Printf("My seat id: %d\n", missingSeatID)
+func parseSeats(input io.Reader) []seat {
+	seats := make([]seat, 0, 10)
+	s := bufio.NewScanner(input)
+	for s.Scan() {
+		seat := newSeat(s.Text(), 128, 8)
+		seats = append(seats, seat)
+	}
+	return seats
 }
 
 func newSeat(code string, totalRowCount int, totalColCount int) seat {
@@ -1,31 +1,39 @@
-package main
+package day6
 
 import (
 	"bufio"
-	"fmt"
 	"io"
-	"log"
-	"os"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	f, err := os.Open("input.txt")
-	if err != nil {
-		log.Fatalf("Could not open input for reading: %v", err)
-	}
-	defer func(f *os.File) {
-		_ = f.Close()
-	}(f)
+func init() {
+	puzzle.Register(2020, 6, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	sumUniqueAnswers    int
+	sumUnanimousAnswers int
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	s.sumUniqueAnswers, s.sumUnanimousAnswers = day6(input)
+	return nil
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return s.sumUniqueAnswers, nil
+}
 
-	day6(f)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return s.sumUnanimousAnswers, nil
 }
 
-func day6(reader io.Reader) {
+func day6(reader io.Reader) (sumUniqueAnswers int, sumUnanimousAnswers int) {
 	scanner := bufio.NewScanner(reader)
 	scanner.Split(emptyLineSplitFunc)
 
-	sumUniqueAnswers, sumUnanimousAnswers := 0, 0
 	for scanner.Scan() {
 		uniqueAnswers := countUniqueAnswersForGroup(scanner.Text())
 		unanimousAnswers := countUnanimousAnswersForGroup(scanner.Text())
@@ -33,8 +41,7 @@ func day6(reader io.Reader) {
 		sumUnanimousAnswers += unanimousAnswers
 	}
 
-	fmt.Printf("Total unique answers: %d\n", sumUniqueAnswers)
-	fmt.Printf("Total unanimous answers: %d\n", sumUnanimousAnswers)
+	return sumUniqueAnswers, sumUnanimousAnswers
 }
 
 func countUniqueAnswersForGroup(group string) int {
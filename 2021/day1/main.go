@@ -1,51 +1,60 @@
-package main
+package day1
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"strconv"
 
 	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file       *os.File
-		windowSize = 1
-		err        error
-	)
+func init() {
+	puzzle.Register(2021, 1, func() puzzle.Solver { return &solver{} })
+}
 
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 3:
-		windowSize, err = strconv.Atoi(os.Args[2])
-		if err != nil {
-			log.Fatalf("Did not understand window size of: %s\n", os.Args[2])
-		}
+type solver struct {
+	input       []byte
+	part1Window int
+	part2Window int
+}
 
-		fallthrough
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
+func (s *solver) Params(params *flag.FlagSet) {
+	params.IntVar(&s.part1Window, "part1-window", 1, "size of the sliding window of depths in part 1")
+	params.IntVar(&s.part2Window, "part2-window", 3, "size of the sliding window of depths in part 2")
+}
+
+func (s *solver) ValidateParams() error {
+	switch {
+	case s.part1Window < 1:
+		return errors.New("part1-window must be at least 1")
+	case s.part2Window < 1:
+		return errors.New("part2-window must be at least 1")
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	return nil
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return depthIncreasesCount(bytes.NewReader(s.input), s.part1Window)
+}
 
-	count := depthIncreasesCount(file, windowSize)
-	fmt.Println(count)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return depthIncreasesCount(bytes.NewReader(s.input), s.part2Window)
 }
 
-func depthIncreasesCount(r io.Reader, windowSize int) int {
+func depthIncreasesCount(r io.Reader, windowSize int) (int, error) {
 	var (
 		count, line int
-		buffer      = data.NewCircularBuffer(windowSize + 1)
+		buffer      = data.NewCircularBuffer[int](windowSize + 1)
 		scanner     = bufio.NewScanner(r)
 	)
 
@@ -53,7 +62,7 @@ func depthIncreasesCount(r io.Reader, windowSize int) int {
 	for scanner.Scan() {
 		current, err := strconv.Atoi(scanner.Text())
 		if err != nil {
-			log.Fatalf("Unexpected error converting '%s' to int", scanner.Text())
+			return 0, fmt.Errorf("unexpected error converting '%s' to int: %w", scanner.Text(), err)
 		}
 
 		buffer.Write(current)
@@ -65,13 +74,13 @@ func depthIncreasesCount(r io.Reader, windowSize int) int {
 		line++
 	}
 
-	return count
+	return count, scanner.Err()
 }
 
-func sumWindow(numbers []interface{}) int {
+func sumWindow(numbers []int) int {
 	var total int
 	for _, v := range numbers {
-		total += v.(int)
+		total += v
 	}
 	return total
 }
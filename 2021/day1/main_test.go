@@ -1,4 +1,4 @@
-package main
+package day1
 
 import (
 	"io"
@@ -51,7 +51,11 @@ func Test_depthIncreasesCount(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := depthIncreasesCount(tt.args.r, tt.args.windowSize); got != tt.want {
+			got, err := depthIncreasesCount(tt.args.r, tt.args.windowSize)
+			if err != nil {
+				t.Fatalf("depthIncreasesCount() error = %v", err)
+			}
+			if got != tt.want {
 				t.Errorf("depthIncreasesCount() = %v, want %v", got, tt.want)
 			}
 		})
@@ -1,15 +1,14 @@
-package main
+package day10
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"sort"
 	"strings"
 
 	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
 type chunk struct {
@@ -37,52 +36,59 @@ type navigationLine struct {
 	autoCompleteScore int
 }
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
-	file, err = os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func init() {
+	puzzle.Register(2021, 10, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	navigationLines []navigationLine
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.navigationLines, err = findCorruptClosingChars(input)
+	return err
+}
 
-	navigationLines := findCorruptClosingChars(file)
-	corruptChars := make([]rune, 50)
-	for _, line := range navigationLines {
+func (s *solver) Part1() (puzzle.Answer, error) {
+	corruptChars := make([]rune, 0, 50)
+	for _, line := range s.navigationLines {
 		if line.errorType == CorruptLine {
 			corruptChars = append(corruptChars, line.corruptChar)
 		}
 	}
-	syntaxScore := errorSyntaxScore(corruptChars)
-	fmt.Printf("syntax-error-score: %d\n", syntaxScore)
+	return errorSyntaxScore(corruptChars), nil
+}
 
+func (s *solver) Part2() (puzzle.Answer, error) {
 	scores := make([]int, 0, 10)
-	for _, line := range navigationLines {
+	for _, line := range s.navigationLines {
 		if line.errorType == IncompleteLine {
 			scores = append(scores, line.autoCompleteScore)
 		}
 	}
+	if len(scores) == 0 {
+		return nil, fmt.Errorf("no incomplete lines found")
+	}
 
 	sort.Ints(scores)
 
-	completeScore := scores[len(scores)/2]
-
-	fmt.Printf("autocomplete-score: %d\n", completeScore)
+	return scores[len(scores)/2], nil
 }
 
-func findCorruptClosingChars(r io.Reader) []navigationLine {
+func findCorruptClosingChars(r io.Reader) ([]navigationLine, error) {
 	var (
 		result = make([]navigationLine, 0, 100)
 	)
 	s := bufio.NewScanner(r)
 	s.Split(bufio.ScanLines)
 	for s.Scan() {
-		newStack := data.NewStack()
+		newStack := data.NewStack[rune]()
 		line := strings.TrimSpace(s.Text())
+		for _, char := range line {
+			if _, found := findMatchingOpeningChar(char); !found && !isOpeningChar(char) {
+				return nil, fmt.Errorf("%q is not a chunk character in line %s", char, line)
+			}
+		}
 		if corruptChar, isCorrupt := isCorruptLine([]rune(line), newStack, 0); isCorrupt {
 			result = append(result, navigationLine{errorType: CorruptLine, corruptChar: corruptChar})
 		} else {
@@ -92,7 +98,7 @@ func findCorruptClosingChars(r io.Reader) []navigationLine {
 				if !found {
 					break
 				}
-				if chunk, found := chunkMap[char.(rune)]; found {
+				if chunk, found := chunkMap[char]; found {
 					missingCloseChars = append(missingCloseChars, chunk.closeChar)
 				}
 			}
@@ -100,10 +106,10 @@ func findCorruptClosingChars(r io.Reader) []navigationLine {
 			result = append(result, navigationLine{errorType: IncompleteLine, autoCompleteScore: completeScore})
 		}
 	}
-	return result
+	return result, s.Err()
 }
 
-func isCorruptLine(chars []rune, stack *data.Stack, currentPosition int) (rune, bool) {
+func isCorruptLine(chars []rune, stack *data.Stack[rune], currentPosition int) (rune, bool) {
 	if currentPosition > len(chars)-1 {
 		return 0, false
 	}
@@ -112,16 +118,13 @@ func isCorruptLine(chars []rune, stack *data.Stack, currentPosition int) (rune,
 		stack.Push(currentChar)
 		return isCorruptLine(chars, stack, currentPosition+1)
 	} else {
-		if matchingOpenChar, found := findMatchingOpeningChar(currentChar); found {
-			if peekChar, found := stack.Peek(); found && peekChar == matchingOpenChar {
-				stack.Pop()
-				return isCorruptLine(chars, stack, currentPosition+1)
-			} else {
-				return currentChar, true
-			}
+		// every other char is a closing char, as the lines are checked as they are read
+		matchingOpenChar, _ := findMatchingOpeningChar(currentChar)
+		if peekChar, found := stack.Peek(); found && peekChar == matchingOpenChar {
+			stack.Pop()
+			return isCorruptLine(chars, stack, currentPosition+1)
 		} else {
-			log.Fatalf("Could not find matching opening char for closing char: %v", currentChar)
-			return 0, false
+			return currentChar, true
 		}
 	}
 }
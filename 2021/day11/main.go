@@ -1,18 +1,14 @@
-package main
+package day11
 
 import (
-	"bufio"
+	"bytes"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
-	"log"
-	"os"
-	"strconv"
-	"strings"
-)
 
-var (
-	grid              = make(map[point]*octopus)
-	cumulativeFlashed = make(map[point]bool, 100)
+	"github.com/neilfenwick/advent-of-code/grid"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
 type flashStats struct {
@@ -20,118 +16,156 @@ type flashStats struct {
 	iterationsWhereAllFlashed        []int
 }
 
-func main() {
-	var (
-		file               *os.File
-		err                error
-		numberOfIterations = 100
-	)
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 3:
-		numberOfIterations, err = strconv.Atoi(os.Args[2])
-		if err != nil {
-			log.Fatalf("Did not understand window size of: %s\n", os.Args[2])
-		}
+// cavern holds the octopuses, along with the ones that have flashed during the
+// current step
+type cavern struct {
+	octopuses *grid.Grid[*octopus]
+	flashed   map[grid.Point]bool
+}
 
-		fallthrough
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
+func init() {
+	puzzle.Register(2021, 11, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	input     []byte
+	steps     int
+	stats     *flashStats
+	octopuses int
+}
+
+func (s *solver) Params(params *flag.FlagSet) {
+	params.IntVar(&s.steps, "steps", 100, "number of steps to count the flashes over in part 1")
+}
+
+func (s *solver) ValidateParams() error {
+	if s.steps < 0 {
+		return errors.New("steps must not be negative")
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	return nil
+}
 
-	buildOctopusMap(file)
-	result := iterateSteps(numberOfIterations)
-	fmt.Printf("%+v\n", *result)
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
 }
 
-func buildOctopusMap(r io.Reader) {
-	var (
-		rowIndex int
-	)
-	s := bufio.NewScanner(r)
-	s.Split(bufio.ScanLines)
-	for s.Scan() {
-		row := []rune(strings.TrimSpace(s.Text()))
-		for i, energy := range row {
-			pnt := point{row: rowIndex, col: i}
-			energyVal, _ := strconv.Atoi(string(energy))
-			octopus := octopus{location: pnt, energy: energyVal}
-			grid[pnt] = &octopus
+func (s *solver) Part1() (puzzle.Answer, error) {
+	c, err := buildOctopusMap(bytes.NewReader(s.input))
+	if err != nil {
+		return nil, err
+	}
+	s.octopuses = c.octopuses.Width() * c.octopuses.Height()
+	s.stats = c.iterateSteps(s.steps)
+	return s.stats.numberOfFlashes, nil
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
+	c, err := buildOctopusMap(bytes.NewReader(s.input))
+	if err != nil {
+		return nil, err
+	}
+	return c.iterateUntilAllFlash(), nil
+}
+
+// Diagnostics reports the steps during part 1 in which every octopus flashed
+func (s *solver) Diagnostics() map[string]any {
+	if s.stats == nil {
+		return nil
+	}
+	return map[string]any{
+		"octopuses":               s.octopuses,
+		"steps":                   s.stats.totalIterations,
+		"steps_where_all_flashed": s.stats.iterationsWhereAllFlashed,
+	}
+}
+
+func buildOctopusMap(r io.Reader) (*cavern, error) {
+	octopuses, err := grid.Parse(r, func(_ grid.Point, energy rune) (*octopus, error) {
+		if energy < '0' || energy > '9' {
+			return nil, fmt.Errorf("%q is not an energy level", energy)
 		}
-		rowIndex++
+		return &octopus{energy: int(energy - '0')}, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &cavern{octopuses: octopuses, flashed: make(map[grid.Point]bool, 100)}, nil
 }
 
-func iterateSteps(count int) *flashStats {
+func (c *cavern) iterateSteps(count int) *flashStats {
 	var (
 		cumulativeFlashCount int
 		result               = flashStats{totalIterations: count, iterationsWhereAllFlashed: make([]int, 0)}
-		mapPoIntegers        = make([]point, 0, len(grid))
 	)
-	for point := range grid {
-		mapPoIntegers = append(mapPoIntegers, point)
-	}
 	for i := 0; i < count; i++ {
-		step(mapPoIntegers)
-		cumulativeFlashCount += len(cumulativeFlashed)
-		if len(cumulativeFlashed) == len(grid) {
+		allFlashed := c.step()
+		cumulativeFlashCount += len(c.flashed)
+		if allFlashed {
 			result.iterationsWhereAllFlashed = append(result.iterationsWhereAllFlashed, i+1)
 		}
-		reset()
+		c.reset()
 	}
 	result.numberOfFlashes = cumulativeFlashCount
 	return &result
 }
 
-func step(poIntegers []point) {
+// iterateUntilAllFlash steps the octopus grid until every octopus flashes
+// during the same step, and returns the number of that step
+func (c *cavern) iterateUntilAllFlash() int {
+	for i := 1; ; i++ {
+		allFlashed := c.step()
+		c.reset()
+		if allFlashed {
+			return i
+		}
+	}
+}
+
+// step raises the energy of every octopus, letting the flashes spread to their
+// neighbours, and reports whether they all flashed
+func (c *cavern) step() bool {
+	points := make([]grid.Point, 0, c.octopuses.Width()*c.octopuses.Height())
+	for p := range c.octopuses.All() {
+		points = append(points, p)
+	}
+	c.stepPoints(points)
+	return len(c.flashed) == len(points)
+}
+
+func (c *cavern) stepPoints(points []grid.Point) {
 	var (
-		flashedPoIntegers = make([]point, 0, 20)
+		flashedPoints = make([]grid.Point, 0, 20)
 	)
-	for _, point := range poIntegers {
-		if octopus, found := grid[point]; found && octopus.Step() {
-			flashedPoIntegers = append(flashedPoIntegers, point)
+	for _, p := range points {
+		if octopus, found := c.octopuses.Get(p); found && octopus.Step() {
+			flashedPoints = append(flashedPoints, p)
 		}
 	}
-	for _, fp := range flashedPoIntegers {
-		cumulativeFlashed[fp] = true
+	for _, fp := range flashedPoints {
+		c.flashed[fp] = true
 	}
-	if len(flashedPoIntegers) > 0 {
-		poIntegersToStep := expandToNeighbours(flashedPoIntegers)
-		step(poIntegersToStep)
+	if len(flashedPoints) > 0 {
+		c.stepPoints(c.expandToNeighbours(flashedPoints))
 	}
 }
 
-func expandToNeighbours(poIntegers []point) []point {
+func (c *cavern) expandToNeighbours(points []grid.Point) []grid.Point {
 	var (
-		result = make([]point, 0, 8*len(poIntegers))
+		result = make([]grid.Point, 0, 8*len(points))
 	)
-	for _, p := range poIntegers {
-		left := point{col: p.col - 1, row: p.row}
-		right := point{col: p.col + 1, row: p.row}
-		up := point{col: p.col, row: p.row - 1}
-		down := point{col: p.col, row: p.row + 1}
-
-		upLeft := point{col: p.col - 1, row: p.row - 1}
-		upRight := point{col: p.col + 1, row: p.row - 1}
-		downLeft := point{col: p.col - 1, row: p.row + 1}
-		downRight := point{col: p.col + 1, row: p.row + 1}
-
-		result = append(result, p, left, right, up, down, upLeft, upRight, downLeft, downRight)
+	for _, p := range points {
+		for neighbour := range c.octopuses.Neighbours8(p) {
+			result = append(result, neighbour)
+		}
 	}
 	return result
 }
 
-func reset() {
-	for point := range cumulativeFlashed {
-		octopus := grid[point]
+func (c *cavern) reset() {
+	for p := range c.flashed {
+		octopus, _ := c.octopuses.Get(p)
 		octopus.Reset()
 	}
-	cumulativeFlashed = make(map[point]bool, 100)
+	c.flashed = make(map[grid.Point]bool, 100)
 }
@@ -1,12 +1,6 @@
-package main
-
-type point struct {
-	col int
-	row int
-}
+package day11
 
 type octopus struct {
-	location   point
 	energy     int
 	hasFlashed bool
 }
@@ -1,169 +1,101 @@
-package main
+package day12
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
-	"os"
-	"strconv"
 	"strings"
 
 	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/memo"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-var (
-	caveGraph = data.NewGraph()
-	allPaths  = make([]data.Stack, 0)
-)
-
-type (
-	canVisitCaveFunc func(node, start *data.Node, visited []*data.Node) bool
-	Strategy         int
-)
+type Strategy int
 
 const (
 	SmallCavesOnce       Strategy = 1
 	SingleSmallCaveTwice Strategy = 2
 )
 
-func main() {
-	var (
-		file     *os.File
-		err      error
-		strategy = 1
-	)
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 3:
-		strategy, err = strconv.Atoi(os.Args[2])
-		if err != nil {
-			log.Fatalf("Did not understand window size of: %s\n", os.Args[2])
-		}
-
-		fallthrough
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func init() {
+	puzzle.Register(2021, 12, func() puzzle.Solver { return &solver{} })
+}
 
-	startName, endName := "start", "end"
-	populateCaveSystemGraph(file)
-	fmt.Printf("Walking from %s to %s with strategy %d\n", startName, endName, strategy)
-	findPaths(startName, endName, Strategy(strategy))
-	fmt.Printf("Found %d paths\n", len(allPaths))
+type solver struct {
+	caves *data.Digraph[string]
 }
 
-func populateCaveSystemGraph(r io.Reader) {
-	var (
-		startNode, endNode *data.Node
-		found              bool
-	)
-	s := bufio.NewScanner(r)
-	s.Split(bufio.ScanLines)
-	for s.Scan() {
-		line := strings.Split(strings.TrimSpace(s.Text()), "-")
-		if startNode, found = caveGraph.GetNode(line[0]); !found {
-			startNode = caveGraph.NewNode(line[0], line[0])
-		}
-		if endNode, found = caveGraph.GetNode(line[1]); !found {
-			endNode = caveGraph.NewNode(line[1], line[1])
-		}
-		caveGraph.LinkNodes(startNode.Name, endNode.Name)
-	}
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.caves, err = populateCaveSystemGraph(input)
+	return err
 }
 
-func findPaths(startName, endName string, strategy Strategy) {
-	var strategyFunc canVisitCaveFunc
-	start, _ := caveGraph.GetNode(startName)
-	end, _ := caveGraph.GetNode(endName)
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return countPaths(s.caves, SmallCavesOnce), nil
+}
 
-	switch strategy {
-	case SmallCavesOnce:
-		strategyFunc = canVisitSmallCavesOnlyOnce
-	case SingleSmallCaveTwice:
-		strategyFunc = canVisitSingleSmallCaveTwice
-	}
-	currentPath := *data.NewStack()
-	walkDepthFirst(start, end, start, currentPath, []*data.Node{}, strategyFunc)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return countPaths(s.caves, SingleSmallCaveTwice), nil
 }
 
-func walkDepthFirst(
-	current, end, start *data.Node,
-	currentPathDepthFirst data.Stack,
-	visitedCurrentTraverse []*data.Node,
-	canVisitFunc canVisitCaveFunc,
-) {
-	currentPathDepthFirst.Push(current)
-	if current == end {
-		allPaths = append(allPaths, *currentPathDepthFirst.Copy())
-		currentPathDepthFirst.Pop()
-		return
-	}
-	visitedCurrentTraverse = append(visitedCurrentTraverse, current)
-	for _, child := range current.Links {
-		if canVisitFunc(start, child, visitedCurrentTraverse) {
-			walkDepthFirst(
-				child,
-				end,
-				start,
-				currentPathDepthFirst,
-				visitedCurrentTraverse,
-				canVisitFunc,
-			)
+// countPaths counts the paths from start to end. How many paths lead on from a cave depends
+// only on which small caves have been visited on the way, and whether one has been visited
+// twice, so the count from each of those states is memoized.
+func countPaths(caves *data.Digraph[string], strategy Strategy) int {
+	smallCaves := indexSmallCaves(caves)
+	paths := memo.New(func(paths func(caveVisit) int, v caveVisit) int {
+		if v.cave == "end" {
+			return 1
 		}
-	}
+		visited := v.visited | smallCaves[v.cave]
+		count := 0
+		for _, link := range caves.Edges(v.cave) {
+			switch {
+			case link.To == "start":
+				continue
+			case visited&smallCaves[link.To] == 0:
+				count += paths(caveVisit{cave: link.To, visited: visited, revisited: v.revisited})
+			case strategy == SingleSmallCaveTwice && !v.revisited:
+				count += paths(caveVisit{cave: link.To, visited: visited, revisited: true})
+			}
+		}
+		return count
+	})
+	return paths.Call(caveVisit{cave: "start"})
 }
 
-// canVisitSmallCavesOnlyOnce returns false if the name is lowercase, and it has already been visited
-func canVisitSmallCavesOnlyOnce(start, node *data.Node, visited []*data.Node) bool {
-	if node == start {
-		return false
-	}
-	if strings.ToUpper(node.Name) == node.Name {
-		return true
-	}
-	for _, seen := range visited {
-		if seen == node {
-			return false
+// caveVisit is a step along a path, into a cave, with the small caves visited before it as
+// a set of bits
+type caveVisit struct {
+	cave      string
+	visited   uint64
+	revisited bool
+}
+
+// indexSmallCaves gives each small cave its own bit, for a set of them to be held in a
+// uint64. Big caves are left as 0, as they can be visited any number of times.
+func indexSmallCaves(caves *data.Digraph[string]) map[string]uint64 {
+	smallCaves := make(map[string]uint64)
+	for _, cave := range caves.Vertices() {
+		if strings.ToLower(cave) == cave {
+			smallCaves[cave] = 1 << len(smallCaves)
 		}
 	}
-	return true
+	return smallCaves
 }
 
-// canVisitSingleSmallCaveTwice returns false if the name is lowercase, and it has already been visited
-// except that one lowercase cave may be visited twice
-func canVisitSingleSmallCaveTwice(start, node *data.Node, visited []*data.Node) bool {
-	var (
-		smallCaveVisitCount   = make(map[string]int)
-		smallCaveLimitReached bool
-	)
-	if node == start {
-		return false
-	}
-	if strings.ToUpper(node.Name) == node.Name {
-		return true
-	}
-	for _, seen := range visited {
-		if strings.ToUpper(seen.Name) == seen.Name {
-			continue
-		}
-		visitCount := smallCaveVisitCount[seen.Name] + 1
-		smallCaveVisitCount[seen.Name] = visitCount
-		if visitCount == 2 && node.Name != seen.Name {
-			smallCaveLimitReached = true
+func populateCaveSystemGraph(r io.Reader) (*data.Digraph[string], error) {
+	caves := data.NewDigraph[string]()
+
+	s := bufio.NewScanner(r)
+	s.Split(bufio.ScanLines)
+	for s.Scan() {
+		line := strings.Split(strings.TrimSpace(s.Text()), "-")
+		if len(line) != 2 {
+			return nil, fmt.Errorf("expected a link between two caves, got %q", s.Text())
 		}
+		caves.AddUndirectedEdge(line[0], line[1], 1)
 	}
-	visitCount := smallCaveVisitCount[node.Name]
-	if smallCaveLimitReached && visitCount > 0 {
-		return false
-	} else {
-		return visitCount < 2
-	}
+	return caves, s.Err()
 }
@@ -0,0 +1,111 @@
+package day12
+
+import (
+	"strings"
+	"testing"
+)
+
+const example = `start-A
+start-b
+A-c
+A-b
+b-d
+A-end
+b-end`
+
+const malformed = "start-A\nA"
+
+func TestSolversDoNotShareState(t *testing.T) {
+	first, second := &solver{}, &solver{}
+	if err := first.Parse(strings.NewReader(example)); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Parse(strings.NewReader("start-A\nA-end")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		part func() (any, error)
+		want int
+	}{
+		{"first Part1", func() (any, error) { return first.Part1() }, 10},
+		{"first Part2", func() (any, error) { return first.Part2() }, 36},
+		{"second Part1", func() (any, error) { return second.Part1() }, 1},
+		{"second Part2", func() (any, error) { return second.Part2() }, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.part()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_MalformedInput(t *testing.T) {
+	if err := (&solver{}).Parse(strings.NewReader(malformed)); err == nil {
+		t.Errorf("Expected an error parsing %q", malformed)
+	}
+}
+
+func Test_countPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		strategy Strategy
+		want     int
+	}{
+		{"small example once", example, SmallCavesOnce, 10},
+		{"small example twice", example, SingleSmallCaveTwice, 36},
+		{"larger example once", largerExample, SmallCavesOnce, 19},
+		{"larger example twice", largerExample, SingleSmallCaveTwice, 103},
+		{"largest example once", largestExample, SmallCavesOnce, 226},
+		{"largest example twice", largestExample, SingleSmallCaveTwice, 3509},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			caves, err := populateCaveSystemGraph(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := countPaths(caves, tt.strategy); got != tt.want {
+				t.Errorf("countPaths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+const largerExample = `dc-end
+HN-start
+start-kj
+dc-start
+dc-HN
+LN-dc
+HN-end
+kj-sl
+kj-HN
+kj-dc`
+
+const largestExample = `fs-end
+he-DX
+fs-he
+start-DX
+pj-DX
+end-zg
+zg-sl
+zg-pj
+pj-he
+RW-he
+fs-DX
+pj-RW
+zg-RW
+start-pj
+he-WI
+zg-he
+pj-fs
+start-RW`
@@ -1,4 +1,4 @@
-package main
+package day13
 
 type foldInstruction interface {
 	fold(data map[point]bool)
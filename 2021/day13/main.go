@@ -1,36 +1,46 @@
-package main
+package day13
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
-	file, err = os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func init() {
+	puzzle.Register(2021, 13, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	input []byte
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
 
-	page := readInputToPage(file)
+func (s *solver) Part1() (puzzle.Answer, error) {
+	page := readInputToPage(bytes.NewReader(s.input))
+	if len(page.instructions) == 0 {
+		return nil, fmt.Errorf("no fold instructions found")
+	}
 	firstFold := page.instructions[0]
 	firstFold.fold(page.data)
-	fmt.Printf("PoIntegers after first fold: %d\n\n", len(page.data))
-	for _, instruction := range page.instructions[1:] {
+	return len(page.data), nil
+}
+
+// Part2 returns the folded page, which has to be read to find the code
+func (s *solver) Part2() (puzzle.Answer, error) {
+	page := readInputToPage(bytes.NewReader(s.input))
+	for _, instruction := range page.instructions {
 		instruction.fold(page.data)
 	}
-	fmt.Print(page.toString())
+	return page.toString(), nil
 }
 
 func readInputToPage(r io.Reader) *page {
@@ -1,32 +1,54 @@
-package main
+package day14
 
 import (
 	"bufio"
-	"fmt"
-	"log"
+	"errors"
+	"flag"
+	"io"
 	"math"
-	"os"
 	"strings"
 
 	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	file, err := os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
+func init() {
+	puzzle.Register(2021, 14, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	template   string
+	polymerMap map[string]rune
+	steps      int
+}
+
+func (s *solver) Params(params *flag.FlagSet) {
+	params.IntVar(&s.steps, "steps", 10, "number of pair insertion steps in part 1")
+}
+
+func (s *solver) ValidateParams() error {
+	if s.steps < 0 {
+		return errors.New("steps must not be negative")
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	return nil
+}
 
-	scanner := bufio.NewScanner(file)
+func (s *solver) Parse(input io.Reader) error {
+	scanner := bufio.NewScanner(input)
 	scanner.Scan()
-	input := scanner.Text()
+	s.template = scanner.Text()
 	scanner.Scan()
-	polymerMap := buildPolymerMap(scanner)
-	min, max := countMinMaxElementOccurrences(input, polymerMap)
-	fmt.Printf("Quantities: most common %d, least common %d. Difference: %d\n", max, min, max-min)
+	s.polymerMap = buildPolymerMap(scanner)
+	return scanner.Err()
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	min, max := countMinMaxElementOccurrences(s.template, s.polymerMap, s.steps)
+	return max - min, nil
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return nil, puzzle.ErrNotImplemented
 }
 
 func buildPolymerMap(scanner *bufio.Scanner) map[string]rune {
@@ -42,20 +64,14 @@ func buildPolymerMap(scanner *bufio.Scanner) map[string]rune {
 	return polymerMap
 }
 
-func countMinMaxElementOccurrences(input string, polymers map[string]rune) (int, int) {
-	elementsLinkedList := data.NewRuneLinkedList([]rune(input))
-	for i := 0; i < 10; i++ {
-		expandElements(elementsLinkedList, polymers)
+func countMinMaxElementOccurrences(input string, polymers map[string]rune, steps int) (int, int) {
+	elements := data.NewList([]rune(input)...)
+	for i := 0; i < steps; i++ {
+		expandElements(elements, polymers)
 	}
 	elementCounts := make(map[rune]int)
-	current := elementsLinkedList.Head
-	for {
-		count := elementCounts[current.Value]
-		elementCounts[current.Value] = count + 1
-		if current.Next == nil {
-			break
-		}
-		current = current.Next
+	for element := range elements.All() {
+		elementCounts[element]++
 	}
 	min, max := math.MaxInt, 0
 	for _, v := range elementCounts {
@@ -69,34 +85,17 @@ func countMinMaxElementOccurrences(input string, polymers map[string]rune) (int,
 	return min, max
 }
 
-func expandElements(elementsList *data.RuneLinkedList, polymers map[string]rune) {
-	currentElement := elementsList.Head
-	newElements := data.NewRuneLinkedList([]rune{})
-	for {
-		if currentElement.Next == nil {
+// expandElements inserts the new element between each pair, in place. The
+// elements inserted are passed over, as they were not part of a pair before
+// the step.
+func expandElements(elements *data.List[rune], polymers map[string]rune) {
+	for element := range elements.Nodes() {
+		next := element.Next()
+		if next == nil {
 			break
 		}
-		pair := []rune{currentElement.Value, currentElement.Next.Value}
-		newElementValue := polymers[string(pair)]
-		newElements.AppendValue(newElementValue)
-		currentElement = currentElement.Next
-	}
-	mergeLinkedLists(elementsList, newElements)
-}
-
-func mergeLinkedLists(first *data.RuneLinkedList, second *data.RuneLinkedList) {
-	currentElement := first.Head
-	currentSecondListElement := second.Head
-	var tempNext *data.RuneLinkedListNode
-	for {
-		tempNext = currentElement.Next
-		if tempNext == nil || currentSecondListElement == nil {
-			return
+		if newElement, found := polymers[string([]rune{element.Value, next.Value})]; found {
+			elements.InsertAfter(newElement, element)
 		}
-		currentElement.Next = currentSecondListElement
-		currentElement = currentSecondListElement
-		currentSecondListElement = currentSecondListElement.Next
-		currentElement.Next = tempNext
-		currentElement = tempNext
 	}
 }
@@ -1,36 +1,36 @@
-package main
+package day2
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
+func init() {
+	puzzle.Register(2021, 2, func() puzzle.Solver { return &solver{} })
+}
 
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+type solver struct {
+	input []byte
+}
 
-	depth, distance := calcPositionWithAim(file)
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	depth, distance := calcPosition(bytes.NewReader(s.input))
+	return depth * distance, nil
+}
 
-	fmt.Println(depth * distance)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	depth, distance := calcPositionWithAim(bytes.NewReader(s.input))
+	return depth * distance, nil
 }
 
 func calcPosition(r io.Reader) (int, int) {
@@ -1,52 +1,56 @@
-package main
+package day3
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"strconv"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
+func init() {
+	puzzle.Register(2021, 3, func() puzzle.Solver { return &solver{} })
+}
 
-	file, err = os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+type solver struct {
+	registers *DiagnosticRegisters
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.registers, err = initDiagnosticRegisters(input)
+	return err
+}
 
-	diagnostics := initDiagnosticRegisters(file)
-	power := diagnostics.PowerConsumption()
-	fmt.Printf("Power consumption: %d\n", power.epsilonRate*power.gammaRate)
+func (s *solver) Part1() (puzzle.Answer, error) {
+	power := s.registers.PowerConsumption()
+	return power.epsilonRate * power.gammaRate, nil
+}
 
-	lifeSupport := diagnostics.LifeSupport()
-	fmt.Printf("Life support: %d\n", lifeSupport.oxygenGenerator*lifeSupport.co2Scrubber)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	lifeSupport := s.registers.LifeSupport()
+	return lifeSupport.oxygenGenerator * lifeSupport.co2Scrubber, nil
 }
 
-func initDiagnosticRegisters(r io.Reader) *DiagnosticRegisters {
+func initDiagnosticRegisters(r io.Reader) (*DiagnosticRegisters, error) {
 	s := bufio.NewScanner(r)
 	s.Split(bufio.ScanWords)
 	s.Scan()
 	d := NewDiagnosticRegisters(len(s.Text()))
-	d.AddReading(textToInt32(s.Text()))
-	for s.Scan() {
-		d.AddReading(textToInt32(s.Text()))
+	for more := true; more; more = s.Scan() {
+		reading, err := textToInt32(s.Text())
+		if err != nil {
+			return nil, err
+		}
+		d.AddReading(reading)
 	}
-	return d
+	return d, s.Err()
 }
 
-func textToInt32(text string) int {
+func textToInt32(text string) (int, error) {
 	i, err := strconv.ParseInt(text, 2, 32)
 	if err != nil {
-		log.Fatalf("Count not convert '%s' to int", text)
+		return 0, fmt.Errorf("could not convert '%s' to int: %w", text, err)
 	}
-	return int(i)
+	return int(i), nil
 }
@@ -1,4 +1,4 @@
-package main
+package day3
 
 import (
 	"io"
@@ -42,7 +42,11 @@ func Test_PowerConsumption(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := initDiagnosticRegisters(tt.args.r).PowerConsumption(); !reflect.DeepEqual(got, tt.want) {
+			registers, err := initDiagnosticRegisters(tt.args.r)
+			if err != nil {
+				t.Fatalf("initDiagnosticRegisters() error = %v", err)
+			}
+			if got := registers.PowerConsumption(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("PowerConsumption() = %v, want %v", got, tt.want)
 			}
 		})
@@ -77,7 +81,11 @@ func Test_LifeSupport(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := initDiagnosticRegisters(tt.args.r).LifeSupport(); !reflect.DeepEqual(got, tt.want) {
+			registers, err := initDiagnosticRegisters(tt.args.r)
+			if err != nil {
+				t.Fatalf("initDiagnosticRegisters() error = %v", err)
+			}
+			if got := registers.LifeSupport(); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("LifeSupport() = %v, want %v", got, tt.want)
 			}
 		})
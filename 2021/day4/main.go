@@ -1,13 +1,13 @@
-package main
+package day4
 
 import (
 	"bufio"
-	"fmt"
+	"bytes"
 	"io"
-	"log"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
 var boards []*board
@@ -19,44 +19,27 @@ const (
 	Lose
 )
 
-func main() {
-	var (
-		file     *os.File
-		err      error
-		strategy WinOrLoseStrategy
-	)
+func init() {
+	puzzle.Register(2021, 4, func() puzzle.Solver { return &solver{} })
+}
 
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 3:
-		switch os.Args[2] {
-		case "Win":
-			fallthrough
-		case "win":
-			strategy = Win
-		case "Lose":
-			fallthrough
-		case "lose":
-			strategy = Lose
-		default:
-			log.Fatalf("Did not understand win/lose strategy of : %s\n", os.Args[2])
-		}
+type solver struct {
+	input []byte
+}
 
-		fallthrough
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	sum, number := findBingoSum(bytes.NewReader(s.input), Win)
+	return sum * number, nil
+}
 
-	sum, number := findBingoSum(file, strategy)
-	result := sum * number
-	fmt.Println(result)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	sum, number := findBingoSum(bytes.NewReader(s.input), Lose)
+	return sum * number, nil
 }
 
 func findBingoSum(r io.Reader, strategy WinOrLoseStrategy) (int, int) {
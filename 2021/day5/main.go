@@ -1,38 +1,44 @@
-package main
+package day5
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-var ventMap = NewVentMap()
+func init() {
+	puzzle.Register(2021, 5, func() puzzle.Solver { return &solver{} })
+}
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
+type solver struct {
+	vectors []*ventVector
+}
 
-	file, err = os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
+func (s *solver) Parse(input io.Reader) error {
+	s.vectors = parseVentLocations(input)
+	return nil
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	vectors := make([]*ventVector, 0, 100)
+	for _, v := range s.vectors {
+		if v.start.x == v.end.x || v.start.y == v.end.y {
+			vectors = append(vectors, v)
+		}
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	return buildVentMap(vectors).CountVentIntersectionsOverThreshold(), nil
+}
 
-	result := countVentDensity(file)
-	fmt.Println(result)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return buildVentMap(s.vectors).CountVentIntersectionsOverThreshold(), nil
 }
 
 func countVentDensity(r io.Reader) int {
 	vectors := parseVentLocations(r)
-	buildVentMap(vectors)
-	return ventMap.CountVentIntersectionsOverThreshold()
+	return buildVentMap(vectors).CountVentIntersectionsOverThreshold()
 }
 
 func parseVentLocations(r io.Reader) []*ventVector {
@@ -56,8 +62,10 @@ func parseVentLocations(r io.Reader) []*ventVector {
 	return result
 }
 
-func buildVentMap(vectors []*ventVector) {
+func buildVentMap(vectors []*ventVector) *sparseVentMap {
+	ventMap := NewVentMap()
 	for _, v := range vectors {
 		ventMap.AddVector(v)
 	}
+	return ventMap
 }
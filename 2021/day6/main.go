@@ -1,51 +1,64 @@
-package main
+package day6
 
 import (
 	"bufio"
+	"bytes"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file         *os.File
-		numberOfDays = 80
-		err          error
-	)
+func init() {
+	puzzle.Register(2021, 6, func() puzzle.Solver { return &solver{} })
+}
 
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 3:
-		numberOfDays, err = strconv.Atoi(os.Args[2])
-		if err != nil {
-			log.Fatalf("Did not understand window size of: %s\n", os.Args[2])
-		}
+type solver struct {
+	input     []byte
+	part1Days int
+	part2Days int
+}
 
-		fallthrough
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
+func (s *solver) Params(params *flag.FlagSet) {
+	params.IntVar(&s.part1Days, "part1-days", 80, "number of days to simulate in part 1")
+	params.IntVar(&s.part2Days, "part2-days", 256, "number of days to simulate in part 2")
+}
+
+func (s *solver) ValidateParams() error {
+	switch {
+	case s.part1Days < 0:
+		return errors.New("part1-days must not be negative")
+	case s.part2Days < 0:
+		return errors.New("part2-days must not be negative")
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	return nil
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
 
-	result := countFishAfterDays(file, numberOfDays)
-	fmt.Println(result)
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return countFishAfterDays(bytes.NewReader(s.input), s.part1Days)
 }
 
-func countFishAfterDays(r io.Reader, numberOfDays int) int64 {
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return countFishAfterDays(bytes.NewReader(s.input), s.part2Days)
+}
+
+func countFishAfterDays(r io.Reader, numberOfDays int) (int64, error) {
 	var (
 		result int64
 	)
-	fish := readFish(r)
+	fish, err := readFish(r)
+	if err != nil {
+		return 0, err
+	}
 
 	for i := 0; i < numberOfDays; i++ {
 		fish = runGeneration(fish)
@@ -54,10 +67,10 @@ func countFishAfterDays(r io.Reader, numberOfDays int) int64 {
 	for _, v := range fish {
 		result += v
 	}
-	return result
+	return result, nil
 }
 
-func readFish(r io.Reader) map[int]int64 {
+func readFish(r io.Reader) (map[int]int64, error) {
 	fish := make(map[int]int64)
 	s := bufio.NewScanner(r)
 	s.Split(bufio.ScanLines)
@@ -66,12 +79,12 @@ func readFish(r io.Reader) map[int]int64 {
 		for _, n := range numbers {
 			f, err := strconv.Atoi(n)
 			if err != nil {
-				log.Fatalf("Could not convert '%s' to int", n)
+				return nil, fmt.Errorf("could not convert '%s' to int: %w", n, err)
 			}
 			fish[f] = fish[f] + 1
 		}
 	}
-	return fish
+	return fish, s.Err()
 }
 
 func runGeneration(fish map[int]int64) map[int]int64 {
@@ -1,14 +1,15 @@
-package main
+package day7
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
-	"log"
 	"math"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
 type alignmentPosition struct {
@@ -22,51 +23,72 @@ type inputPositions struct {
 	max           int
 }
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
+func init() {
+	puzzle.Register(2021, 7, func() puzzle.Solver { return &solver{} })
+}
 
-	file, err = os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+type solver struct {
+	input []byte
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	alignment, err := calcLeastEditDistance(bytes.NewReader(s.input), constantFuelCost)
+	return alignment.fuelCost, err
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
+	alignment, err := calcLeastEditDistance(bytes.NewReader(s.input), increasingFuelCost)
+	return alignment.fuelCost, err
+}
+
+// fuelCostFunc returns the fuel used by a crab to move the given distance
+type fuelCostFunc func(distance int64) int64
+
+func constantFuelCost(distance int64) int64 {
+	return distance
+}
 
-	result := calcLeastEditDistance(file)
-	fmt.Printf("%+v\n", result)
+func increasingFuelCost(distance int64) int64 {
+	var fuelCost int64
+	for d := distance; d > 0; d-- {
+		fuelCost = fuelCost + d
+	}
+	return fuelCost
 }
 
-func calcLeastEditDistance(r io.Reader) alignmentPosition {
-	inputPositions := readInputPositions(r)
+func calcLeastEditDistance(r io.Reader, fuelCost fuelCostFunc) (alignmentPosition, error) {
+	inputPositions, err := readInputPositions(r)
+	if err != nil {
+		return alignmentPosition{}, err
+	}
 	allPositions := make(map[int]alignmentPosition)
 	for i := inputPositions.min; i <= inputPositions.max; i++ {
 		var (
-			fuelCost int64
+			totalFuelCost int64
 		)
 		for _, pos := range inputPositions.positionArray {
 			distanceToMove := int64(math.Abs(float64(pos) - float64(i)))
-			for d := distanceToMove; d > 0; d-- {
-				fuelCost = fuelCost + d
-			}
+			totalFuelCost += fuelCost(distanceToMove)
 		}
-		allPositions[i] = alignmentPosition{position: i, fuelCost: fuelCost}
+		allPositions[i] = alignmentPosition{position: i, fuelCost: totalFuelCost}
 	}
 
 	// find the lowest fuel cost
-	result := alignmentPosition{fuelCost: math.MaxInt32}
+	result := alignmentPosition{fuelCost: math.MaxInt64}
 	for _, alignment := range allPositions {
 		if alignment.fuelCost < result.fuelCost {
 			result = alignment
 		}
 	}
-	return result
+	return result, nil
 }
 
-func readInputPositions(r io.Reader) *inputPositions {
+func readInputPositions(r io.Reader) (*inputPositions, error) {
 	var (
 		result = inputPositions{}
 	)
@@ -82,7 +104,7 @@ func readInputPositions(r io.Reader) *inputPositions {
 		for _, n := range numbers {
 			pos, err := strconv.Atoi(n)
 			if err != nil {
-				log.Fatalf("Could not convert '%s' to int", n)
+				return nil, fmt.Errorf("could not convert '%s' to int: %w", n, err)
 			}
 			if pos < result.min {
 				result.min = pos
@@ -93,5 +115,5 @@ func readInputPositions(r io.Reader) *inputPositions {
 			result.positionArray = append(result.positionArray, pos)
 		}
 	}
-	return &result
+	return &result, s.Err()
 }
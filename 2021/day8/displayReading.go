@@ -1,48 +1,60 @@
-package main
+package day8
+
+import (
+	"slices"
+
+	data "github.com/neilfenwick/advent-of-code/data_structures"
+)
 
 type displayReading struct {
-	numberMap map[string]int
-	notes     []string
+	numberMap map[data.RuneSet]int
+	notes     []data.RuneSet
 }
 
 func NewDisplayReading(signalPatterns []string, notes []string) *displayReading {
 	var (
-		input   = make([]intersectingString, len(signalPatterns))
-		numbers = make([]intersectingString, len(signalPatterns))
+		input   = make([]data.RuneSet, len(signalPatterns))
+		numbers = make([]data.RuneSet, len(signalPatterns))
 	)
 
-	numberMap := make(map[string]int, len(numbers))
+	numberMap := make(map[data.RuneSet]int, len(numbers))
 
 	for i, v := range signalPatterns {
-		input[i] = intersectingString(stringSort(v))
+		input[i] = data.NewRuneSet(v)
 	}
 
-	search := searchableStringSlice(input)
+	first := func(f func(s data.RuneSet) bool) data.RuneSet {
+		if i := slices.IndexFunc(input, f); i >= 0 {
+			return input[i]
+		}
+		return 0
+	}
 
-	numbers[1] = search.First(func(s intersectingString) bool { return len(s) == 2 })
-	numbers[7] = search.First(func(s intersectingString) bool { return len(s) == 3 })
-	numbers[4] = search.First(func(s intersectingString) bool { return len(s) == 4 })
-	numbers[8] = search.First(func(s intersectingString) bool { return len(s) == 7 })
-	numbers[9] = search.First(func(s intersectingString) bool { return len(s) == 6 && len(s.Intersect(numbers[4])) == 4 })
-	numbers[0] = search.First(func(s intersectingString) bool {
-		return len(s) == 6 && s != numbers[9] && len(s.Intersect(numbers[1])) == 2
+	numbers[1] = first(func(s data.RuneSet) bool { return s.Count() == 2 })
+	numbers[7] = first(func(s data.RuneSet) bool { return s.Count() == 3 })
+	numbers[4] = first(func(s data.RuneSet) bool { return s.Count() == 4 })
+	numbers[8] = first(func(s data.RuneSet) bool { return s.Count() == 7 })
+	numbers[9] = first(func(s data.RuneSet) bool { return s.Count() == 6 && s.And(numbers[4]).Count() == 4 })
+	numbers[0] = first(func(s data.RuneSet) bool {
+		return s.Count() == 6 && s != numbers[9] && s.And(numbers[1]).Count() == 2
 	})
-	numbers[6] = search.First(func(s intersectingString) bool {
-		return len(s) == 6 && s != numbers[9] && s != numbers[0]
+	numbers[6] = first(func(s data.RuneSet) bool {
+		return s.Count() == 6 && s != numbers[9] && s != numbers[0]
 	})
-	numbers[3] = search.First(func(s intersectingString) bool { return len(s) == 5 && len(s.Intersect(numbers[1])) == 2 })
-	numbers[5] = search.First(func(s intersectingString) bool {
-		return len(s) == 5 && s != numbers[3] && len(s.Intersect(numbers[9])) == 5
+	numbers[3] = first(func(s data.RuneSet) bool { return s.Count() == 5 && s.And(numbers[1]).Count() == 2 })
+	numbers[5] = first(func(s data.RuneSet) bool {
+		return s.Count() == 5 && s != numbers[3] && s.And(numbers[9]).Count() == 5
 	})
-	numbers[2] = search.First(func(s intersectingString) bool { return len(s) == 5 && s != numbers[3] && s != numbers[5] })
+	numbers[2] = first(func(s data.RuneSet) bool { return s.Count() == 5 && s != numbers[3] && s != numbers[5] })
 
-	for i := 0; i < len(notes); i++ {
-		notes[i] = stringSort(notes[i])
+	noteSets := make([]data.RuneSet, len(notes))
+	for i, note := range notes {
+		noteSets[i] = data.NewRuneSet(note)
 	}
 
 	for i, v := range numbers {
-		numberMap[string(v)] = i
+		numberMap[v] = i
 	}
 
-	return &displayReading{numberMap: numberMap, notes: notes}
+	return &displayReading{numberMap: numberMap, notes: noteSets}
 }
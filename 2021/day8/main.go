@@ -1,57 +1,59 @@
-package main
+package day8
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
 	"math"
-	"os"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-var readings = make([]*displayReading, 0, 100)
+func init() {
+	puzzle.Register(2021, 8, func() puzzle.Solver { return &solver{} })
+}
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
+type solver struct {
+	readings []*displayReading
+}
 
-	file, err = os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.readings, err = readInputs(input)
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return countUniqueValues(s.readings), nil
+}
 
-	readings = readInputs(file)
-	uniqueCount := countUniqueValues()
-	sum := sumReadings()
-	fmt.Printf("Unique Count: %d\nSum: %d\n", uniqueCount, sum)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return sumReadings(s.readings), nil
 }
 
-func readInputs(r io.Reader) []*displayReading {
+func readInputs(r io.Reader) ([]*displayReading, error) {
 	result := make([]*displayReading, 0, 100)
 	s := bufio.NewScanner(r)
 	s.Split(bufio.ScanLines)
 	for s.Scan() {
 		line := s.Text()
 		parts := strings.Split(line, "|")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("expected notes | display in %q", line)
+		}
 		displayReading := NewDisplayReading(strings.Fields(parts[0]), strings.Fields(parts[1]))
 		result = append(result, displayReading)
 	}
-	return result
+	return result, s.Err()
 }
 
-func countUniqueValues() int {
+func countUniqueValues(readings []*displayReading) int {
 	var (
 		result int
 	)
 	for _, r := range readings {
 		for _, n := range r.notes {
-			switch len(n) {
+			switch n.Count() {
 			case 2, 3, 4, 7:
 				result++
 			}
@@ -60,7 +62,7 @@ func countUniqueValues() int {
 	return result
 }
 
-func sumReadings() int {
+func sumReadings(readings []*displayReading) int {
 	var (
 		sum int
 	)
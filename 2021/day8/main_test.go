@@ -0,0 +1,58 @@
+package day8
+
+import (
+	"strings"
+	"testing"
+)
+
+const example = `be cfbegad cbdgef fgaecd cgeb fdcge agebfd fecdb fabcd edb | fdgacbe cefdb cefbgd gcbe
+edbfga begcd cbg gc gcadebf fbgde acbgfd abcde gfcbed gfec | fcgedb cgb dgebacf gc
+fgaebd cg bdaec gdafb agbcfd gdcbef bgcad gfac gcb cdgabef | cg cg fdcagb cbg
+fbegcd cbd adcefb dageb afcb bc aefdc ecdab fgdeca fcdbega | efabcd cedba gadfec cb
+aecbfdg fbg gf bafeg dbefa fcge gcbea fcaegb dgceab fcbdga | gecf egdcabf bgf bfgea
+fgeab ca afcebg bdacfeg cfaedg gcfdb baec bfadeg bafgc acf | gebdcfa ecba ca fadegcb
+dbcfg fgd bdegcaf fgec aegbdf ecdfab fbedc dacgb gdcebf gf | cefg dcbef fcge gbcadfe
+bdfegc cbegaf gecbf dfcage bdacg ed bedf ced adcbefg gebcd | ed bcgafe cdgba cbgef
+egadfb cdbfeg cegd fecab cgb gbdefca cg fgcdab egfdb bfceg | gbdfcae bgc cg cgb
+gcafb gcf dcaebfg ecagb gf abcdeg gaef cafbge fdbac fegbdc | fgae cfgab fg bagce
+`
+
+const malformed = "be cfbegad cbdgef fgaecd cgeb fdcge agebfd fecdb fabcd edb"
+
+func TestSolversDoNotShareState(t *testing.T) {
+	first, second := &solver{}, &solver{}
+	if err := first.Parse(strings.NewReader(example)); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Parse(strings.NewReader("be cfbegad cbdgef fgaecd cgeb fdcge agebfd fecdb fabcd edb | fdgacbe cefdb cefbgd gcbe")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		part func() (any, error)
+		want int
+	}{
+		{"first Part1", func() (any, error) { return first.Part1() }, 26},
+		{"first Part2", func() (any, error) { return first.Part2() }, 61229},
+		{"second Part1", func() (any, error) { return second.Part1() }, 2},
+		{"second Part2", func() (any, error) { return second.Part2() }, 8394},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.part()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_MalformedInput(t *testing.T) {
+	if err := (&solver{}).Parse(strings.NewReader(malformed)); err == nil {
+		t.Errorf("Expected an error parsing %q", malformed)
+	}
+}
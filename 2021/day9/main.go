@@ -1,186 +1,95 @@
-package main
+package day9
 
 import (
-	"bufio"
-	"fmt"
 	"io"
-	"log"
-	"os"
 	"sort"
-	"strings"
-)
 
-var heightmap = make(map[int][]int, 100)
+	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/grid"
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
 
-type point struct {
-	x, y int
+func init() {
+	puzzle.Register(2021, 9, func() puzzle.Solver { return &solver{} })
 }
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
-
-	file, err = os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
-
-	buildHeightMap(file)
-
-	lowPointRiskSum := sumLowPointRisk()
-	fmt.Printf("Low point risk sum: %d\n", lowPointRiskSum)
-
-	basinSizeSum := findProductOfBasinSizes()
-	fmt.Printf("Basin size sum: %d\n", basinSizeSum)
+type solver struct {
+	heightmap *grid.Grid[int]
 }
 
-func buildHeightMap(r io.Reader) {
-	var (
-		rowIndex int
-	)
+func (s *solver) Parse(input io.Reader) error {
+	var err error
+	s.heightmap, err = grid.ParseDigits(input)
+	return err
+}
 
-	s := bufio.NewScanner(r)
-	s.Split(bufio.ScanLines)
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return sumLowPointRisk(s.heightmap), nil
+}
 
-	for s.Scan() {
-		inputRow := []rune(strings.TrimSpace(s.Text()))
-		if len(inputRow) == 0 {
-			continue
-		}
-		row := make([]int, 0, len(inputRow))
-		for _, d := range inputRow {
-			row = append(row, int(d)-48) // minus 48 to get from ascii table index to actual int value
-		}
-		heightmap[rowIndex] = row
-		rowIndex++
-	}
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return findProductOfBasinSizes(s.heightmap), nil
 }
 
-func sumLowPointRisk() int {
+func sumLowPointRisk(heightmap *grid.Grid[int]) int {
 	var (
 		sum int
 	)
-	lowPointValues, _ := scanForLowPoIntegers()
-	for _, v := range lowPointValues {
-		sum += 1 + v
+	for _, p := range findLowPoints(heightmap) {
+		height, _ := heightmap.Get(p)
+		sum += 1 + height
 	}
 	return sum
 }
 
-func scanForLowPoIntegers() ([]int, []point) {
+// findLowPoints returns the locations that are lower than all of their
+// neighbours.
+func findLowPoints(heightmap *grid.Grid[int]) []grid.Point {
 	var (
-		lowPointHeights = make([]int, 0, 50)
-		lowPoIntegers   = make([]point, 0, 50)
+		lowPoints = make([]grid.Point, 0, 50)
 	)
 
-	for y := 0; y < len(heightmap); y++ {
-		currentRow := heightmap[y]
-		for x := 0; x < len(currentRow); x++ {
-			location := point{x: x, y: y}
-			lowerPoIntegers := findNeighboursHigherOrLower(location, Lower)
-			if len(lowerPoIntegers) == 0 {
-				lowPointHeights = append(lowPointHeights, currentRow[x])
-				lowPoIntegers = append(lowPoIntegers, location)
+	for location, height := range heightmap.All() {
+		isLowest := true
+		for _, neighbour := range heightmap.Neighbours4(location) {
+			if neighbour <= height {
+				isLowest = false
+				break
 			}
 		}
+		if isLowest {
+			lowPoints = append(lowPoints, location)
+		}
 	}
-	return lowPointHeights, lowPoIntegers
+	return lowPoints
 }
 
-type HigherOrLowerStrategy int
-
-const (
-	Lower  HigherOrLowerStrategy = -1
-	Higher HigherOrLowerStrategy = 1
-)
-
-func findNeighboursHigherOrLower(p point, strategy HigherOrLowerStrategy) []point {
-	// Not great for readability. This func needs a refactor.
-	// The fact that it was tricky to write, suggests the design might not be good.
+// findProductOfBasinSizes multiplies the sizes of the three largest basins.
+// Every location that is not a 9 belongs to a basin, along with its neighbours
+// that are not 9s.
+func findProductOfBasinSizes(heightmap *grid.Grid[int]) int {
 	var (
-		result = make([]point, 0, 4)
+		sum    = 1
+		basins = data.NewUnionFind[grid.Point]()
+		// only the neighbours already visited, a row at a time from the top left
+		visited = []grid.Vector{{X: -1, Y: 0}, {X: 0, Y: -1}}
 	)
 
-	currentRow, found := heightmap[p.y]
-	if !found {
-		return []point{}
-	}
-	currentVal := currentRow[p.x]
-
-	up := point{y: p.y - 1, x: p.x}
-	if upVal, found := getValue(up); found && (upVal-currentVal)*int(strategy) >= 0 {
-		switch {
-		case strategy == Higher && upVal != 9:
-			fallthrough
-		case strategy == Lower:
-			result = append(result, up)
-		}
-	}
-
-	right := point{y: p.y, x: p.x + 1}
-	if rightVal, found := getValue(right); found && (rightVal-currentVal)*int(strategy) >= 0 {
-		switch {
-		case strategy == Higher && rightVal != 9:
-			fallthrough
-		case strategy == Lower:
-			result = append(result, right)
-		}
-	}
-
-	down := point{y: p.y + 1, x: p.x}
-	if downVal, found := getValue(down); found && (downVal-currentVal)*int(strategy) >= 0 {
-		switch {
-		case strategy == Higher && downVal != 9:
-			fallthrough
-		case strategy == Lower:
-			result = append(result, down)
+	for location, height := range heightmap.All() {
+		if height == 9 {
+			continue
 		}
-	}
-
-	left := point{y: p.y, x: p.x - 1}
-	if leftVal, found := getValue(left); found && (leftVal-currentVal)*int(strategy) >= 0 {
-		switch {
-		case strategy == Higher && leftVal != 9:
-			fallthrough
-		case strategy == Lower:
-			result = append(result, left)
+		basins.Add(location)
+		for neighbour, value := range heightmap.Neighbours(location, visited) {
+			if value != 9 {
+				basins.Union(location, neighbour)
+			}
 		}
 	}
 
-	return result
-}
-
-func getValue(p point) (int, bool) {
-	if p.x < 0 {
-		return 0, false
-	}
-
-	row, found := heightmap[p.y]
-	if !found || p.x >= len(row) {
-		return 0, false
-	}
-
-	return row[p.x], true
-}
-
-func findProductOfBasinSizes() int {
-	var (
-		sum        = 1
-		basinSizes = make([]int, 0, 100)
-	)
-
-	_, lowPoIntegers := scanForLowPoIntegers()
-
-	for _, lowPoint := range lowPoIntegers {
-		neighbourMap := make(map[point]bool)
-		neighbourMap[lowPoint] = true
-		searchMapForHigherPoIntegers(&neighbourMap)
-		basinSizes = append(basinSizes, len(neighbourMap))
+	basinSizes := make([]int, 0, basins.Count())
+	for _, basin := range basins.Components() {
+		basinSizes = append(basinSizes, len(basin))
 	}
 
 	sort.Ints(basinSizes)
@@ -191,18 +100,3 @@ func findProductOfBasinSizes() int {
 	}
 	return sum
 }
-
-func searchMapForHigherPoIntegers(poIntegers *map[point]bool) {
-	currentCount := len(*poIntegers)
-	floorMap := *poIntegers
-	for p := range floorMap {
-		higherNeighbours := findNeighboursHigherOrLower(p, Higher)
-		for _, neighbour := range higherNeighbours {
-			floorMap[neighbour] = true
-		}
-		newCount := len(floorMap)
-		if newCount > currentCount {
-			searchMapForHigherPoIntegers(poIntegers)
-		}
-	}
-}
@@ -1,8 +1,10 @@
-package main
+package day9
 
 import (
 	"strings"
 	"testing"
+
+	"github.com/neilfenwick/advent-of-code/grid"
 )
 
 func Test_findProductOfBasinSizes(t *testing.T) {
@@ -21,9 +23,12 @@ func Test_findProductOfBasinSizes(t *testing.T) {
 				8767896789
 				9899965678`)
 
-			buildHeightMap(input)
+			heightmap, err := grid.ParseDigits(input)
+			if err != nil {
+				t.Fatal(err)
+			}
 
-			if got := findProductOfBasinSizes(); got != tt.want {
+			if got := findProductOfBasinSizes(heightmap); got != tt.want {
 				t.Errorf("findProductOfBasinSizes() = %v, want %v", got, tt.want)
 			}
 		})
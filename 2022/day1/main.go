@@ -1,38 +1,43 @@
-package main
+package day1
 
 import (
 	"bufio"
 	"fmt"
 	"io"
 	"log"
-	"os"
 	"sort"
 	"strconv"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
+func init() {
+	puzzle.Register(2022, 1, func() puzzle.Solver { return &solver{} })
+}
 
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 3:
-		fallthrough
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
+type solver struct {
+	foodPacks []foodPack
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	s.foodPacks = buildFoodPacks(input)
+	sortCalories(s.foodPacks)
+	return nil
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	if len(s.foodPacks) == 0 {
+		return nil, fmt.Errorf("no food packs found")
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	return s.foodPacks[0].calories, nil
+}
 
-	foodPacks := buildFoodPacks(file)
-	printCalories(foodPacks)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	total := 0
+	for i := 0; i < 3 && i < len(s.foodPacks); i++ {
+		total += s.foodPacks[i].calories
+	}
+	return total, nil
 }
 
 type foodPack struct {
@@ -64,13 +69,15 @@ func buildFoodPacks(reader io.Reader) []foodPack {
 		}
 		pack.addFood(calories)
 	}
+	if pack.calories > 0 {
+		packs = append(packs, pack)
+	}
 	return packs
 }
 
-// printCalories prints the sorted (desc) calories values
-func printCalories(foodPacks []foodPack) {
+// sortCalories sorts the food packs by calories (desc)
+func sortCalories(foodPacks []foodPack) {
 	sort.Slice(foodPacks, func(i, j int) bool {
 		return foodPacks[i].calories > foodPacks[j].calories
 	})
-	fmt.Println(foodPacks)
 }
@@ -1,49 +1,45 @@
-package main
+package day2
 
 import (
 	"bufio"
-	"fmt"
+	"bytes"
 	"io"
-	"log"
-	"os"
-	"strconv"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
+func init() {
+	puzzle.Register(2022, 2, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	input []byte
+}
 
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
+
+// Part1 reads the rounds again in each part, because the scoring strategy is
+// applied as the rounds are read
+func (s *solver) Part1() (puzzle.Answer, error) {
 	strategy = scoreAsMove
+	return totalScore(readRounds(bytes.NewReader(s.input))), nil
+}
 
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 3:
-		scoringStrategy, _ := strconv.Atoi(os.Args[2])
-		if scoringStrategy > 0 {
-			strategy = scoreAsResult
-		}
-		fallthrough
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	strategy = scoreAsResult
+	return totalScore(readRounds(bytes.NewReader(s.input))), nil
+}
 
-	rounds := readRounds(file)
+func totalScore(rounds []game) int {
 	var result int
-
 	for _, rnd := range rounds {
 		result += rnd.score
 	}
-	fmt.Println(result)
+	return result
 }
 
 var strategy scoringStrategy
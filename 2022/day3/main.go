@@ -1,136 +1,86 @@
-package main
+package day3
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
-	"log"
-	"os"
-	"sort"
-	"strconv"
+	"slices"
+
+	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
-
-	priorityStrategy = calculatePrioritiesPart1
-
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 3:
-		scoringStrategy, _ := strconv.Atoi(os.Args[2])
-		if scoringStrategy > 0 {
-			priorityStrategy = calculatePrioritiesPart2
-		}
-		fallthrough
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func init() {
+	puzzle.Register(2022, 3, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	input []byte
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
 
-	ruckSacks := priorityStrategy(file)
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return sumPriorities(calculatePrioritiesPart1(bytes.NewReader(s.input)))
+}
 
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return sumPriorities(calculatePrioritiesPart2(bytes.NewReader(s.input)))
+}
+
+func sumPriorities(ruckSacks []ruckSack) (int, error) {
 	sum := 0
 	for _, ruckSack := range ruckSacks {
-		sum += ruckSack.getPriorityScore()
+		score, err := ruckSack.getPriorityScore()
+		if err != nil {
+			return 0, err
+		}
+		sum += score
 	}
-
-	fmt.Printf("Priority sum: %d\n", sum)
+	return sum, nil
 }
 
+// ruckSack holds the items in each compartment, or in each rucksack of a group
+// of elves
 type ruckSack struct {
-	compartment1 []rune
-	compartment2 []rune
-	compartment3 []rune
-	priorityItem rune
+	compartments []data.RuneSet
 }
 
-var priorityStrategy strategy
-
-type strategy func(file io.Reader) []ruckSack
-
-func (r *ruckSack) calculatePriorityItem() {
-	/*
-	   Start by sorting the items in each compartment and then comparing each
-	   compartment by walking them in order, similar to merge sort, to find the first
-	   item that exists in both compartments
-	*/
-	sort.Slice(r.compartment1, func(i, j int) bool { return r.compartment1[i] < r.compartment1[j] })
-	sort.Slice(r.compartment2, func(i, j int) bool { return r.compartment2[i] < r.compartment2[j] })
-	sort.Slice(r.compartment3, func(i, j int) bool { return r.compartment3[i] < r.compartment3[j] })
-
-	var currentRune rune
-	i, j, k := 0, 0, 0
-
-	for i < len(r.compartment1) {
-		currentRune = r.compartment1[i]
-
-		for j < len(r.compartment2) {
-			testRune := r.compartment2[j]
-			if currentRune == testRune {
-
-				if len(r.compartment3) > 0 {
-					for k < len(r.compartment3) {
-						testRuneInner := r.compartment3[k]
-						if testRune == testRuneInner {
-							goto foundPriorityItem
-						} else if testRune > testRuneInner {
-							k++
-							continue
-						} else if testRune < testRuneInner {
-							break
-						}
-					}
-					break
-				} else {
-					goto foundPriorityItem
-				}
-			} else if currentRune > testRune {
-				j++
-				continue
-			} else if currentRune < testRune {
-				break
-			}
-		}
-		i++
+// priorityItem finds the one item that is in every compartment
+func (r *ruckSack) priorityItem() (rune, error) {
+	common := r.compartments[0]
+	for _, compartment := range r.compartments[1:] {
+		common = common.And(compartment)
 	}
-
-foundPriorityItem:
-	r.priorityItem = currentRune
+	items := slices.Collect(common.All())
+	if len(items) != 1 {
+		return 0, fmt.Errorf("expected one item in every compartment of %v, found %q", r, common)
+	}
+	return items[0], nil
 }
 
-func (r *ruckSack) getPriorityScore() int {
+func (r *ruckSack) getPriorityScore() (int, error) {
 	/*
-	  Go runes for 'a-z' run from 97-122
-	  Go runes for 'A-Z' run from 65-90
 	  This function needs to return 1-26 for 'a-z' and 27-52 for 'A-Z'
 	*/
-	r.calculatePriorityItem()
-	priorityScore := int(r.priorityItem)
+	item, err := r.priorityItem()
+	if err != nil {
+		return 0, err
+	}
 
-	if 97 <= priorityScore && priorityScore <= 122 {
-		return priorityScore - 96
+	if 'a' <= item && item <= 'z' {
+		return int(item-'a') + 1, nil
 	}
 
-	return priorityScore - 64 + 26
+	return int(item-'A') + 27, nil
 }
 
 func (r *ruckSack) String() string {
-	return fmt.Sprintf(
-		"RuckSack:\nCompartment1: %v\nCompartment2: %v\nCompartment3: %v\nPriorityItem: %s\n",
-		string(r.compartment1),
-		string(r.compartment2),
-		string(r.compartment3),
-		string(r.priorityItem))
+	return fmt.Sprintf("RuckSack%v", r.compartments)
 }
 
 func calculatePrioritiesPart1(file io.Reader) []ruckSack {
@@ -139,10 +89,14 @@ func calculatePrioritiesPart1(file io.Reader) []ruckSack {
 	ruckSacks := []ruckSack{}
 
 	for s.Scan() {
-		pack := ruckSack{}
 		items := s.Text()
-		pack.compartment1 = []rune(items[0 : len(items)/2])
-		pack.compartment2 = []rune(items[len(items)/2:])
+		if items == "" {
+			continue
+		}
+		pack := ruckSack{compartments: []data.RuneSet{
+			data.NewRuneSet(items[0 : len(items)/2]),
+			data.NewRuneSet(items[len(items)/2:]),
+		}}
 		ruckSacks = append(ruckSacks, pack)
 	}
 
@@ -153,21 +107,17 @@ func calculatePrioritiesPart2(file io.Reader) []ruckSack {
 	s := bufio.NewScanner(file)
 	s.Split(bufio.ScanLines)
 	ruckSacks := []ruckSack{}
-	lines := make([]string, 3)
-	i := 1
+	pack := ruckSack{}
 
 	for s.Scan() {
-
-		lines[i-1] = s.Text()
-		if i%3 == 0 {
-			pack := ruckSack{}
-			pack.compartment1 = []rune(lines[0])
-			pack.compartment2 = []rune(lines[1])
-			pack.compartment3 = []rune(lines[2])
+		if s.Text() == "" {
+			continue
+		}
+		pack.compartments = append(pack.compartments, data.NewRuneSet(s.Text()))
+		if len(pack.compartments) == 3 {
 			ruckSacks = append(ruckSacks, pack)
-			i = 0
+			pack = ruckSack{}
 		}
-		i++
 	}
 	return ruckSacks
 }
@@ -1,109 +1,78 @@
-package main
+package day4
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
-	"os"
+
+	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
+func init() {
+	puzzle.Register(2022, 4, func() puzzle.Solver { return &solver{} })
+}
 
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 3:
-		fallthrough
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+type solver struct {
+	pairs []assignmentPair
+}
 
-	assignments := readAssignments(file)
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.pairs, err = readAssignments(input)
+	return err
+}
 
+func (s *solver) Part1() (puzzle.Answer, error) {
 	enclosedCount := 0
-	for _, pair := range assignments {
-		_, found := pair.enclosedAssignment()
-		if found {
+	for _, pair := range s.pairs {
+		if pair.first.ContainsInterval(pair.second) || pair.second.ContainsInterval(pair.first) {
 			enclosedCount++
 		}
 	}
+	return enclosedCount, nil
+}
 
-	fmt.Printf("Found %d enclosed assignments\n", enclosedCount)
-
+func (s *solver) Part2() (puzzle.Answer, error) {
 	overlapCount := 0
-	for _, pair := range assignments {
-		_, found := pair.overlappedAssignment()
-		if found {
+	for _, pair := range s.pairs {
+		if pair.first.Overlaps(pair.second) {
 			overlapCount++
 		}
 	}
-
-	fmt.Printf("Found %d overlapped assignments\n", overlapCount)
-}
-
-type assignment struct {
-	start int
-	end   int
+	return overlapCount, nil
 }
 
+// assignmentPair holds the sections assigned to each elf of a pair
 type assignmentPair struct {
-	first  assignment
-	second assignment
-}
-
-func (pair *assignmentPair) enclosedAssignment() (*assignment, bool) {
-	if pair.first.start >= pair.second.start &&
-		pair.first.end <= pair.second.end {
-		return &pair.first, true
-	}
-
-	if pair.second.start >= pair.first.start &&
-		pair.second.end <= pair.first.end {
-		return &pair.second, true
-	}
-
-	return &assignment{}, false
-}
-
-func (pair *assignmentPair) overlappedAssignment() (*assignment, bool) {
-	if pair.first.start <= pair.second.end &&
-		pair.first.end >= pair.second.start {
-		return &pair.first, true
-	}
-
-	return &assignment{}, false
+	first  data.Interval[int]
+	second data.Interval[int]
 }
 
-func readAssignments(file io.Reader) []assignmentPair {
+func readAssignments(file io.Reader) ([]assignmentPair, error) {
 	s := bufio.NewScanner(file)
 	var rangeOneStart, rangeOneEnd, rangeTwoStart, rangeTwoEnd int
 	assignments := []assignmentPair{}
 
 	for s.Scan() {
-		fmt.Sscanf(
+		if s.Text() == "" {
+			continue
+		}
+		if _, err := fmt.Sscanf(
 			s.Text(),
 			"%d-%d,%d-%d",
 			&rangeOneStart,
 			&rangeOneEnd,
 			&rangeTwoStart,
-			&rangeTwoEnd)
+			&rangeTwoEnd); err != nil {
+			return nil, fmt.Errorf("reading assignment pair %q: %w", s.Text(), err)
+		}
 
-		asssignmentOne := assignment{start: rangeOneStart, end: rangeOneEnd}
-		asssignmentTwo := assignment{start: rangeTwoStart, end: rangeTwoEnd}
-		pair := assignmentPair{first: asssignmentOne, second: asssignmentTwo}
+		pair := assignmentPair{
+			first:  data.InclusiveInterval(rangeOneStart, rangeOneEnd),
+			second: data.InclusiveInterval(rangeTwoStart, rangeTwoEnd),
+		}
 		assignments = append(assignments, pair)
 	}
 
-	return assignments
+	return assignments, s.Err()
 }
@@ -1,44 +1,58 @@
-package main
+package day5
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
 	"log"
-	"os"
-	"sort"
-	"strconv"
+	"slices"
 	"strings"
 
 	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
-
-	strategy = processMoveInstructions
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 3:
-		inputStrategy, _ := strconv.Atoi(os.Args[2])
-		if inputStrategy > 0 {
-			strategy = processMoveInstructionsPart2
-		}
-		fallthrough
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
+func init() {
+	puzzle.Register(2022, 5, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	input []byte
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
+
+// Part1 reads the stacks again in each part, because the crane moves the crates
+// as the instructions are read
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return topCrates(processStacks(bytes.NewReader(s.input), processMoveInstructions)), nil
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return topCrates(processStacks(bytes.NewReader(s.input), processMoveInstructionsPart2)), nil
+}
+
+// topCrates returns the crate on top of each stack, or a space for an empty stack
+func topCrates(stacks []*data.Stack[rune]) string {
+	builder := strings.Builder{}
+	for _, s := range stacks {
+		crate, found := s.Peek()
+		if !found {
+			builder.WriteRune(' ')
+		} else {
+			builder.WriteRune(crate)
 		}
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	return builder.String()
+}
+
+type craneStrategy func(s *bufio.Scanner, crates []*data.Stack[rune])
 
+func processStacks(input io.Reader, strategy craneStrategy) []*data.Stack[rune] {
 	/*
 	   This is a stacking problem. Parse the input into N stacks
 	   Each input header is fixed width, so it is possible to determine the number
@@ -59,29 +73,8 @@ func main() {
 	   about with synchronising access to copies of slices.
 	*/
 
-	stacks := processStacks(file)
-	for _, s := range stacks {
-		var result string
-
-		crate, found := s.Pop()
-		if !found {
-			result = " "
-		} else {
-			result = fmt.Sprintf("%s", string(crate.(rune)))
-		}
-		fmt.Print(result)
-	}
-
-	fmt.Println()
-}
-
-type craneStrategy func(s *bufio.Scanner, crates []*data.Stack)
-
-var strategy craneStrategy
-
-func processStacks(file *os.File) []*data.Stack {
-	s := bufio.NewScanner(file)
-	var queues []*data.Queue
+	s := bufio.NewScanner(input)
+	var queues []*data.Queue[rune]
 
 	for s.Scan() {
 		if strings.HasPrefix(s.Text(), " 1") {
@@ -111,35 +104,31 @@ func processStacks(file *os.File) []*data.Stack {
 	return stacks
 }
 
-func convertToStacks(queues []*data.Queue) []*data.Stack {
-	stacks := make([]*data.Stack, len(queues))
+func convertToStacks(queues []*data.Queue[rune]) []*data.Stack[rune] {
+	stacks := make([]*data.Stack[rune], len(queues))
 
 	for p, q := range queues {
+		// the top crate was read first
 		items := q.Items()
-		// reverse the items
-		sort.SliceStable(items, func(i, j int) bool {
-			return i > j
-		})
-		stacks[p] = data.NewStackFromItems(q.Items())
+		slices.Reverse(items)
+		stacks[p] = data.NewStackFromItems(items)
 	}
 
 	return stacks
 }
 
-func setupCrateQueues(lineLength int) []*data.Queue {
+func setupCrateQueues(lineLength int) []*data.Queue[rune] {
 	numberOfStacks := (lineLength + 1) / 4
-	result := make([]*data.Queue, numberOfStacks)
+	result := make([]*data.Queue[rune], numberOfStacks)
 
 	for i := 0; i < numberOfStacks; i++ {
-		result[i] = data.NewQueue()
+		result[i] = data.NewQueue[rune]()
 	}
 
 	return result
 }
 
-func processMoveInstructions(s *bufio.Scanner, crates []*data.Stack) {
-	printCrates(crates)
-
+func processMoveInstructions(s *bufio.Scanner, crates []*data.Stack[rune]) {
 	for s.Scan() {
 		if s.Err() == io.EOF {
 			break
@@ -157,13 +146,9 @@ func processMoveInstructions(s *bufio.Scanner, crates []*data.Stack) {
 		}
 	}
 
-	printCrates(crates)
 }
 
-func processMoveInstructionsPart2(s *bufio.Scanner, crates []*data.Stack) {
-	fmt.Println("Processing strategy part2")
-	printCrates(crates)
-
+func processMoveInstructionsPart2(s *bufio.Scanner, crates []*data.Stack[rune]) {
 	for s.Scan() {
 		if s.Err() == io.EOF {
 			break
@@ -172,7 +157,7 @@ func processMoveInstructionsPart2(s *bufio.Scanner, crates []*data.Stack) {
 		count, fromIndex, toIndex := 0, 0, 0
 		fmt.Sscanf(s.Text(), "move %d from %d to %d", &count, &fromIndex, &toIndex)
 
-		subStack := data.NewStack()
+		subStack := data.NewStack[rune]()
 		for i := 0; i < count; i++ {
 			crate, found := crates[fromIndex-1].Pop()
 			if !found {
@@ -186,37 +171,4 @@ func processMoveInstructionsPart2(s *bufio.Scanner, crates []*data.Stack) {
 		}
 	}
 
-	printCrates(crates)
-}
-
-func printCrates(crates []*data.Stack) {
-	longest := 0
-	stacks := make([]*data.Stack, len(crates))
-
-	for p, s := range crates {
-		stacks[p] = s.Copy()
-	}
-
-	for _, s := range stacks {
-		if s.Size() > longest {
-			longest = s.Size()
-		}
-	}
-
-	for i := longest; i > 0; i-- {
-		builder := strings.Builder{}
-		for _, q := range stacks {
-			if q.Size() >= i {
-				val, _ := q.Pop()
-				builder.WriteRune('[')
-				builder.WriteRune(val.(rune))
-				builder.WriteString("] ")
-			} else {
-				builder.WriteString("    ")
-			}
-		}
-		fmt.Println(builder.String())
-	}
-
-	fmt.Println()
 }
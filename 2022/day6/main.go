@@ -1,76 +1,69 @@
-package main
+package day6
 
 import (
 	"bufio"
-	"fmt"
-	"log"
-	"os"
-	"strconv"
+	"bytes"
+	"errors"
+	"flag"
+	"io"
 
 	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
+func init() {
+	puzzle.Register(2022, 6, func() puzzle.Solver { return &solver{} })
+}
 
-	signalLength := 0
+type solver struct {
+	input        []byte
+	part1Markers int
+	part2Markers int
+}
 
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 3:
-		signalLength, _ = strconv.Atoi(os.Args[2])
-		fallthrough
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
+func (s *solver) Params(params *flag.FlagSet) {
+	params.IntVar(&s.part1Markers, "packet", 4, "number of distinct characters in a start-of-packet marker")
+	params.IntVar(&s.part2Markers, "message", 14, "number of distinct characters in a start-of-message marker")
+}
+
+func (s *solver) ValidateParams() error {
+	switch {
+	case s.part1Markers < 1:
+		return errors.New("packet must be at least 1")
+	case s.part2Markers < 1:
+		return errors.New("message must be at least 1")
 	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	return nil
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return searchForMarker(bytes.NewReader(s.input), s.part1Markers), nil
+}
 
-	count := searchForMarker(file, signalLength)
-	fmt.Printf("Token count until complete signal: %d\n", count)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return searchForMarker(bytes.NewReader(s.input), s.part2Markers), nil
 }
 
-func searchForMarker(file *os.File, signalLength int) int {
-	s := bufio.NewScanner(file)
+// searchForMarker returns the number of characters read when the last
+// signalLength characters are all different
+func searchForMarker(input io.Reader, signalLength int) int {
+	s := bufio.NewScanner(input)
 	s.Split(bufio.ScanRunes)
 
-	buffer := data.NewCircularBuffer(signalLength)
+	buffer := data.NewCountingBuffer[rune](signalLength)
 	tokenCount := 0
 
 	for s.Scan() {
 		tokenCount++
-		r := []rune(s.Text())[0]
-		buffer.Write(r)
-		if isHeterogenousTokens(buffer) {
+		buffer.Write([]rune(s.Text())[0])
+		if buffer.Full() && buffer.Distinct() == signalLength {
 			return tokenCount
 		}
 	}
 	return 0
 }
-
-func isHeterogenousTokens(buffer *data.CircularBuffer) bool {
-	dict := make(map[rune]int, buffer.Size())
-	data := buffer.Read(0, buffer.Size())
-	for _, r := range data {
-		if r == nil {
-			return false
-		}
-
-		_, found := dict[r.(rune)]
-		if found {
-			return false
-		}
-
-		dict[r.(rune)] = 1
-	}
-
-	return true
-}
@@ -1,219 +1,148 @@
-package main
+package day7
 
 import (
 	"bufio"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
+	"io"
 	"math"
-	"os"
-	"sort"
 	"strconv"
 	"strings"
 
 	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
-
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 3:
-		_, _ = strconv.Atoi(os.Args[2])
-		fallthrough
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
-
-	/*
-	   Because this problem looked like a file tree calculation, I just used a tree data
-	   structure from previous years.  In hindsight, that old tree wasn't the best
-	   implementation. And it could probably do with being re-written with generics.
+func init() {
+	puzzle.Register(2022, 7, func() puzzle.Solver { return &solver{} })
+}
 
-	   This solution could probably have been achieved by just passing a hashtable around
-	   and doing a recursive parse of the input.
+type solver struct {
+	fileTree   *data.GenericTree[entry]
+	diskSize   int
+	updateSize int
+}
 
-	   The solution feels a bit scatter-brained and unelegant 😕
-	*/
+func (s *solver) Params(params *flag.FlagSet) {
+	params.IntVar(&s.diskSize, "disk-size", 70_000_000, "total size of the disk")
+	params.IntVar(&s.updateSize, "update-size", 30_000_000, "free space needed to install the update")
+}
 
-	fileTree := processTerminalOutput(file)
+func (s *solver) ValidateParams() error {
+	switch {
+	case s.diskSize < 0:
+		return errors.New("disk-size must not be negative")
+	case s.updateSize < 0:
+		return errors.New("update-size must not be negative")
+	case s.updateSize > s.diskSize:
+		return errors.New("update-size must not be larger than disk-size")
+	}
+	return nil
+}
 
-	fmt.Printf("*************\nPart 1:\n*************\n")
-	results := searchDirectoriesMaxSize(fileTree, 100000)
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.fileTree, err = processTerminalOutput(input)
+	return err
+}
 
+func (s *solver) Part1() (puzzle.Answer, error) {
 	totalSize := 0
-	for _, size := range results {
-		totalSize += size
+	for _, size := range directorySizes(s.fileTree) {
+		if size <= 100000 {
+			totalSize += size
+		}
 	}
-	fmt.Printf("Total size: %d\n", totalSize)
+	return totalSize, nil
+}
 
-	fmt.Printf("*************\nPart 2:\n*************\n")
-	results = searchDirectoriesMaxSize(fileTree, math.MaxInt)
+// Part2 returns the size of the smallest directory that frees up enough space
+func (s *solver) Part2() (puzzle.Answer, error) {
+	sizes := directorySizes(s.fileTree)
 
-	rootSize, _ := results["$root"]
-	spaceRemaining := 70_000_000 - rootSize
-	requiredToFree := 30_000_000 - spaceRemaining
+	spaceRemaining := s.diskSize - sizes[s.fileTree.Root]
+	requiredToFree := s.updateSize - spaceRemaining
 
-	largeDirectories := make([]directory, 0)
-	for name, size := range results {
+	smallest := math.MaxInt
+	for _, size := range sizes {
 		if size >= requiredToFree {
-			largeDirectories = append(largeDirectories, directory{name: name, size: size})
-			fmt.Printf("%s: %d\n", name, size)
+			smallest = min(smallest, size)
 		}
 	}
-	sort.Slice(largeDirectories, func(i, j int) bool {
-		return largeDirectories[i].size < largeDirectories[j].size
-	})
-	fmt.Printf("Directory to delete: %v\n", largeDirectories[0])
-}
-
-type termLine struct {
-	prefix string
-	suffix string
-}
-
-type file struct {
-	name string
-	size int
+	if smallest == math.MaxInt {
+		return nil, fmt.Errorf("no directory frees up %d", requiredToFree)
+	}
+	return smallest, nil
 }
 
-type directory struct {
-	name        string
-	files       []file
-	directories []directory
-	size        int
+// entry is a file or directory in the file tree. Directories are given no size
+// of their own.
+type entry struct {
+	name  string
+	size  int
+	isDir bool
 }
 
-func processTerminalOutput(inputFile *os.File) *data.GenericTree[any] {
-	s := bufio.NewScanner(inputFile)
-	var t *data.GenericTree[any]
-	var currentNode *data.GenericTreeNode[any]
+func processTerminalOutput(input io.Reader) (*data.GenericTree[entry], error) {
+	s := bufio.NewScanner(input)
+	t := data.NewGenericTree(entry{name: "/", isDir: true})
+	currentNode := t.Root
 
 	for s.Scan() {
 		line := s.Text()
+		fields := strings.Fields(line)
 
-		if line == "$ cd /" {
-			log.Println("$root")
-			t = data.NewGenericTree(any(directory{name: "$root"}))
-			currentNode = t.Root
+		switch {
+		case len(fields) == 0 || line == "$ ls":
 			continue
-		}
-
-		if strings.HasPrefix(line, "$ cd") {
-			var dest string
-			fmt.Sscanf(line, "$ cd %s", &dest)
-			if dest == ".." {
-				if currentNode.Parent != nil {
-					currentNode = currentNode.Parent
-				}
-			} else {
-				var found *data.GenericTreeNode[any]
-				for _, child := range currentNode.Children {
-					if dir, ok := child.Value.(directory); ok && dir.name == dest {
-						found = child
-						break
-					}
-				}
-				if found != nil {
-					currentNode = found
-				}
+		case line == "$ cd /":
+			currentNode = t.Root
+		case line == "$ cd ..":
+			if currentNode.Parent != nil {
+				currentNode = currentNode.Parent
+			}
+		case strings.HasPrefix(line, "$ cd "):
+			dest := strings.TrimPrefix(line, "$ cd ")
+			if dir, found := findChild(currentNode, dest); found {
+				currentNode = dir
 			}
-		} else if strings.HasPrefix(line, "$ ls") {
-			for s.Scan() {
-				line := s.Text()
-				if strings.HasPrefix(line, "$") {
-					// This is a command line, process it in the next iteration
-					// But we already consumed it, so we need to handle it now
-					if strings.HasPrefix(line, "$ cd") {
-						var dest string
-						fmt.Sscanf(line, "$ cd %s", &dest)
-						if dest == ".." {
-							if currentNode.Parent != nil {
-								currentNode = currentNode.Parent
-							}
-						} else {
-							var found *data.GenericTreeNode[any]
-							for _, child := range currentNode.Children {
-								if dir, ok := child.Value.(directory); ok && dir.name == dest {
-									found = child
-									break
-								}
-							}
-							if found != nil {
-								currentNode = found
-							}
-						}
-					}
-					break
-				}
-				parts := strings.Fields(line)
-				if len(parts) < 2 {
-					continue
-				}
-				if parts[0] == "dir" {
-					log.Printf("Appending directory '%s'\n", parts[1])
-					dir := directory{name: parts[1]}
-					currentNode.AddChild(any(dir))
-				} else {
-					size, err := strconv.Atoi(parts[0])
-					if err == nil {
-						log.Printf("Parsing file: %s, size: %d\n", parts[1], size)
-						f := file{name: parts[1], size: size}
-						currentNode.AddChild(any(f))
-					}
-				}
+		case fields[0] == "dir" && len(fields) == 2:
+			currentNode.AddChild(entry{name: fields[1], isDir: true})
+		case len(fields) == 2:
+			size, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return nil, fmt.Errorf("reading file size %q: %w", line, err)
 			}
+			currentNode.AddChild(entry{name: fields[1], size: size})
+		default:
+			return nil, fmt.Errorf("unexpected terminal output %q", line)
 		}
 	}
 
-	return t
+	return t, s.Err()
 }
 
-func searchDirectoriesMaxSize(fileTree *data.GenericTree[any], maxSizeThreshold int) map[string]int {
-	allDirectorySizes := make(map[string]int)
-	root := fileTree.Root
-	if rootDir, ok := root.Value.(directory); ok {
-		allDirectorySizes[rootDir.name] = 0
-	}
-	searchDirectoriesRecursive(root, allDirectorySizes)
-
-	results := make(map[string]int)
-	for key, value := range allDirectorySizes {
-		if value <= maxSizeThreshold {
-			results[key] = value
+func findChild(dir *data.GenericTreeNode[entry], name string) (*data.GenericTreeNode[entry], bool) {
+	for _, child := range dir.Children {
+		if child.Value.isDir && child.Value.name == name {
+			return child, true
 		}
 	}
-
-	return results
+	return nil, false
 }
 
-func searchDirectoriesRecursive(treeNode *data.GenericTreeNode[any], directorySizeMap map[string]int) {
-	dir, ok := treeNode.Value.(directory)
-	if !ok {
-		return // skip non-directory nodes
-	}
-
-	totalSize := 0
-	for _, child := range treeNode.Children {
-		switch childVal := child.Value.(type) {
-		case directory:
-			searchDirectoriesRecursive(child, directorySizeMap)
-			if size, ok := directorySizeMap[childVal.name]; ok {
-				totalSize += size
-			}
-		case file:
-			totalSize += childVal.size
+// directorySizes totals the size of the files beneath each directory
+func directorySizes(fileTree *data.GenericTree[entry]) map[*data.GenericTreeNode[entry]]int {
+	sizes := make(map[*data.GenericTreeNode[entry]]int)
+	data.Fold(fileTree.Root, func(node *data.GenericTreeNode[entry], children []int) int {
+		size := node.Value.size
+		for _, childSize := range children {
+			size += childSize
 		}
-	}
-	directorySizeMap[dir.name] = totalSize
+		if node.Value.isDir {
+			sizes[node] = size
+		}
+		return size
+	})
+	return sizes
 }
@@ -1,125 +1,107 @@
-package main
+package day1
 
 import (
 	"bufio"
-	"errors"
+	"bytes"
 	"fmt"
 	"io"
 	"log"
-	"os"
-	"strconv"
-	"strings"
+	"maps"
+
+	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
+func init() {
+	puzzle.Register(2023, 1, func() puzzle.Solver { return &solver{} })
+}
 
-	file, err = os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+type solver struct {
+	input []byte
+}
 
-	coordsPt1 := parseCoordinates(file, func(i string) string { return i })
-	sumPt1 := sumCoords(coordsPt1)
-	fmt.Printf("Part1 sum: %d\n", sumPt1)
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
 
-	file.Seek(0, 0)
+func (s *solver) Part1() (puzzle.Answer, error) {
+	coordsPt1 := parseCoordinates(bytes.NewReader(s.input), digitMatcher)
+	return sumCoords(coordsPt1), nil
+}
 
-	coordsPt2 := parseCoordinates(file, sequentialReplaceNumberWords)
-	sumPt2 := sumCoords(coordsPt2)
-	fmt.Printf("Part2 sum: %d\n", sumPt2)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	coordsPt2 := parseCoordinates(bytes.NewReader(s.input), digitOrWordMatcher)
+	return sumCoords(coordsPt2), nil
 }
 
-var wordToDigitMap = map[string]rune{
-	"one":   '1',
-	"two":   '2',
-	"three": '3',
-	"four":  '4',
-	"five":  '5',
-	"six":   '6',
-	"seven": '7',
-	"eight": '8',
-	"nine":  '9',
+var digitToValueMap = map[string]int{
+	"0": 0, "1": 1, "2": 2, "3": 3, "4": 4, "5": 5, "6": 6, "7": 7, "8": 8, "9": 9,
 }
 
-type digitParseFuncStrategy func(string) string
-
-func sequentialReplaceNumberWords(line string) string {
-	replacer := strings.NewReplacer(
-		"one", "1",
-		"two", "2",
-		"three", "3",
-		"four", "4",
-		"five", "5",
-		"six", "6",
-		"seven", "7",
-		"eight", "8",
-		"nine", "9")
-
-	return replacer.Replace(line)
+var wordToValueMap = map[string]int{
+	"one":   1,
+	"two":   2,
+	"three": 3,
+	"four":  4,
+	"five":  5,
+	"six":   6,
+	"seven": 7,
+	"eight": 8,
+	"nine":  9,
 }
 
-func parseCoordinates(reader io.Reader, parser digitParseFuncStrategy) []string {
+// The matchers find every digit in a line, including number words that overlap
+// such as "oneight", which replacing the words in the line would not
+var (
+	digitMatcher       = data.NewMatcher(digitToValueMap)
+	digitOrWordMatcher = data.NewMatcher(merge(digitToValueMap, wordToValueMap))
+)
+
+func merge(a, b map[string]int) map[string]int {
+	merged := maps.Clone(a)
+	maps.Copy(merged, b)
+	return merged
+}
+
+func parseCoordinates(reader io.Reader, digits *data.Matcher[int]) []int {
 	scanner := bufio.NewScanner(reader)
-	results := make([]string, 0)
+	results := make([]int, 0)
 	for scanner.Scan() {
-		line := parser(scanner.Text())
-		first, err := findFirstDigit(line)
+		line := scanner.Text()
+		value, err := calibrationValue(line, digits)
 		if err != nil {
 			log.Println(err)
 			continue
 		}
-
-		digits := string(first)
-		last, err := findLastDigit(line)
-		if err != nil {
-			log.Println(err)
-			continue
-		}
-
-		digits += string(last)
-		results = append(results, digits)
+		results = append(results, value)
 	}
 
 	return results
 }
 
-func findFirstDigit(line string) (rune, error) {
-	for _, char := range line {
-		switch char {
-		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-			return char, nil
-		}
+// calibrationValue combines the first and last digits in the line into a two
+// digit number
+func calibrationValue(line string, digits *data.Matcher[int]) (int, error) {
+	matches := digits.FindAll(line)
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("no digit found: %s", line)
 	}
 
-	return 0, errors.New("no digit found:" + line)
-}
-
-func findLastDigit(line string) (rune, error) {
-	for i := len(line) - 1; i >= 0; i-- {
-		switch line[i] {
-		case '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
-			return rune(line[i]), nil
+	first, last := matches[0], matches[0]
+	for _, match := range matches[1:] {
+		if match.End > last.End {
+			last = match
 		}
 	}
 
-	return 0, errors.New("no last digit found")
+	return first.Value*10 + last.Value, nil
 }
 
-func sumCoords(coords []string) int {
+func sumCoords(coords []int) int {
 	var result int
-	for _, txt := range coords {
-		val, err := strconv.Atoi(txt)
-		if err != nil {
-			fmt.Printf("Could not convert %s to int\n", txt)
-		}
-		result += val
+	for _, value := range coords {
+		result += value
 	}
 
 	return result
@@ -0,0 +1,33 @@
+package day1
+
+import "testing"
+
+func TestCalibrationValue(t *testing.T) {
+	tests := []struct {
+		line string
+		want int
+	}{
+		{"two1nine", 29},
+		{"7pqrstsixteen", 76},
+		{"eightwo", 82},
+		{"oneight", 18},
+		{"xtwone3four", 24},
+		{"twone", 21},
+		{"5", 55},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			got, err := calibrationValue(tt.line, digitOrWordMatcher)
+			if err != nil {
+				t.Fatalf("calibrationValue() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("calibrationValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := calibrationValue("oneight", digitMatcher); err == nil {
+		t.Error("Expected an error when a line has no digits")
+	}
+}
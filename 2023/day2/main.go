@@ -1,4 +1,25 @@
-package main
+package day2
 
-func main() {
+import (
+	"io"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+func init() {
+	puzzle.Register(2023, 2, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct{}
+
+func (s *solver) Parse(input io.Reader) error {
+	return nil
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return nil, puzzle.ErrNotImplemented
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return nil, puzzle.ErrNotImplemented
 }
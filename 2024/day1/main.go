@@ -1,42 +1,59 @@
-package main
+package day1
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
-	"os"
+	"slices"
 	"sort"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
-
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func init() {
+	puzzle.Register(2024, 1, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	left, right []int
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.left, s.right, err = parseLocations(input)
+	return err
+}
 
-	left, right := parseLocations(file)
+func (s *solver) Part1() (puzzle.Answer, error) {
+	left, right := slices.Clone(s.left), slices.Clone(s.right)
 	sort.Ints(left)
 	sort.Ints(right)
 
-	part1(left, right)
-	part2(left, right)
+	total := 0
+
+	for i := range left {
+		total += abs(left[i] - right[i])
+	}
+
+	return total, nil
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
+	total := 0
+	rightGroup := make(map[int]int)
+
+	for i := range s.right {
+		rightGroup[s.right[i]]++
+	}
+
+	for i := range s.left {
+		leftValue := s.left[i]
+		total += leftValue * rightGroup[leftValue]
+	}
+
+	return total, nil
 }
 
-func parseLocations(file io.Reader) ([]int, []int) {
+func parseLocations(file io.Reader) ([]int, []int, error) {
 
 	left := make([]int, 0, 1000)
 	right := make([]int, 0, 1000)
@@ -44,33 +61,26 @@ func parseLocations(file io.Reader) ([]int, []int) {
 
 	for scanner.Scan() {
 		line := scanner.Text()
-		loc1, loc2 := parseLine(line)
+		loc1, loc2, err := parseLine(line)
+		if err != nil {
+			return nil, nil, err
+		}
 		left = append(left, loc1)
 		right = append(right, loc2)
 	}
 
-	return left, right
+	return left, right, scanner.Err()
 }
 
-func parseLine(line string) (int, int) {
+func parseLine(line string) (int, int, error) {
 	var id1, id2 int
 
 	_, err := fmt.Sscanf(line, "%d %d", &id1, &id2)
 	if err != nil {
-		log.Fatalf("Error parsing line: %s", line)
-	}
-
-	return id1, id2
-}
-
-func part1(left, right []int) {
-	total := 0
-
-	for i := range left {
-		total += abs(left[i] - right[i])
+		return 0, 0, fmt.Errorf("error parsing line %q: %w", line, err)
 	}
 
-	fmt.Printf("Part 1 Total: %d\n", total)
+	return id1, id2, nil
 }
 
 func abs(x int) int {
@@ -79,19 +89,3 @@ func abs(x int) int {
 	}
 	return x
 }
-
-func part2(left, right []int) {
-	total := 0
-	rightGroup := make(map[int]int)
-
-	for i := range right {
-		rightGroup[right[i]]++
-	}
-
-	for i := range left {
-		leftValue := left[i]
-		total += leftValue * rightGroup[leftValue]
-	}
-
-	fmt.Printf("Part 2 Total: %d\n", total)
-}
@@ -1,37 +1,38 @@
-package main
+package day2
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"strconv"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
-
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func init() {
+	puzzle.Register(2024, 2, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	reports [][]int
+}
+
+func (s *solver) Parse(input io.Reader) error {
+	s.reports = parseReports(input)
+	return nil
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return analyzeReports(s.reports, undampedReportAnaylyzer), nil
+}
+
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return analyzeReports(s.reports, dampedReportAnaylyzer), nil
+}
 
-	reports := parseReports(file)
-	analyzeReports(reports, undampedReportAnaylyzer)
-	analyzeReports(reports, dampedReportAnaylyzer)
+func (s *solver) Diagnostics() map[string]any {
+	return map[string]any{"reports": len(s.reports)}
 }
 
 type reportAnalyzer func([]int) bool
@@ -59,7 +60,7 @@ func parseReports(file io.Reader) [][]int {
 	return reports
 }
 
-func analyzeReports(reports [][]int, analyzer reportAnalyzer) {
+func analyzeReports(reports [][]int, analyzer reportAnalyzer) int {
 	safeCount := 0
 
 	for _, report := range reports {
@@ -68,7 +69,7 @@ func analyzeReports(reports [][]int, analyzer reportAnalyzer) {
 		}
 	}
 
-	fmt.Printf("Safe reports: %d\n", safeCount)
+	return safeCount
 }
 
 func undampedReportAnaylyzer(report []int) bool {
@@ -1,43 +1,42 @@
-package main
+package day3
 
 import (
 	"bufio"
 	"bytes"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"regexp"
 	"strconv"
+
+	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
-
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func init() {
+	re = regexp.MustCompile(pattern)
+	puzzle.Register(2024, 3, func() puzzle.Solver { return &solver{} })
+}
 
-	operandsList := parseInput(file)
+type solver struct {
+	input []byte
+}
+
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
+
+func (s *solver) Part1() (puzzle.Answer, error) {
+	operandsList := parseInput(bytes.NewReader(s.input))
 
 	sum := 0
 	for _, operands := range operandsList {
 		sum += operands.first * operands.second
 	}
-	fmt.Printf("Sum of all multiplications: %d\n", sum)
+	return sum, nil
+}
 
+func (s *solver) Part2() (puzzle.Answer, error) {
 	/* Part 2: Wow did I do this the hard way!!!
 	* Because part 1 was done with a relatively simple regex,
 	* and because I wanted to stick with built-in funcionality, I decided to use bufio.Scanner
@@ -46,18 +45,13 @@ func main() {
 	* until a stop token is found, and only resume consuming data when a start token is found.
 
 	 */
-	file.Seek(0, 0)
-	operandsListPart2 := parseInputPart2(file)
+	operandsListPart2 := parseInputPart2(bytes.NewReader(s.input))
 
 	sumPart2 := 0
 	for _, operands := range operandsListPart2 {
 		sumPart2 += operands.first * operands.second
 	}
-	fmt.Printf("Sum of all multiplications: %d\n", sumPart2)
-}
-
-func init() {
-	re = regexp.MustCompile(pattern)
+	return sumPart2, nil
 }
 
 // pattern is a regex that matches the format "mul(x,y)" where x and y are numbers.
@@ -77,13 +71,6 @@ type operands struct {
 func parseInput(file io.Reader) []operands {
 	operandsList := make([]operands, 0)
 
-	// Open part1.txt for writing
-	outputFile, err := os.Create("part1_" + os.Args[1])
-	if err != nil {
-		log.Fatalf("Error creating output file: %s", err)
-	}
-	defer outputFile.Close()
-
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -113,16 +100,9 @@ func parseOperands(line string, operandsList []operands) []operands {
 	return operandsList
 }
 
-func parseInputPart2(file *os.File) []operands {
+func parseInputPart2(file io.Reader) []operands {
 	operandsList := make([]operands, 0)
 
-	// Open part2.txt for writing
-	outputFile, err := os.Create("part2_" + os.Args[1])
-	if err != nil {
-		log.Fatalf("Error creating output file: %s", err)
-	}
-	defer outputFile.Close()
-
 	scanner := bufio.NewScanner(file)
 	scanner.Split(customStopStartTokenScanner)
 
@@ -229,18 +209,18 @@ func rewindForPartialMulMatch(data []byte, rewind int) int {
 	return rewind
 }
 
-var partialTokenMatches [][]byte = [][]byte{
-	[]byte("d"), []byte("do"), []byte("do("),
-	[]byte("don"), []byte("don'"), []byte("don't"), []byte("don't("),
-	[]byte("m"), []byte("mu"), []byte("mul"), []byte("mul("),
-}
-
-func rewindForPartialTokenMatch(data []byte, rewind int) int {
-	for _, partialTokenMatch := range partialTokenMatches {
-		index := bytes.LastIndex(data, partialTokenMatch)
-		if index > -1 && index == len(data)-len(partialTokenMatch) {
-			return rewindForPartialTokenMatch(data[:index], rewind+len(partialTokenMatch))
-		}
+// tokenMatcher tracks how much of the end of the buffer could be the start of a
+// token, so that a token split across two reads is not lost
+var tokenMatcher = data.NewMatcher(map[string]struct{}{
+	"mul(":    {},
+	"do()":    {},
+	"don't()": {},
+})
+
+func rewindForPartialTokenMatch(buf []byte, rewind int) int {
+	state := tokenMatcher.Start()
+	for _, b := range buf {
+		state = state.Next(b)
 	}
-	return rewind
+	return rewind + state.PrefixLen()
 }
@@ -1,70 +1,41 @@
-package main
+package day4
 
 import (
-	"bufio"
 	"io"
 	"log"
-	"os"
-)
-
-func main() {
-	file, err := os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
 
-	mapData := parseMap(file)
-	wordCount := countWords(mapData, []rune{'X', 'M', 'A', 'S'})
-	log.Printf("Word count: %d", wordCount)
+	"github.com/neilfenwick/advent-of-code/geometry"
+	"github.com/neilfenwick/advent-of-code/grid"
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
 
-	xmasCount := countX(mapData, []rune{'M', 'A', 'S'})
-	log.Printf("XMAS count: %d", xmasCount)
+func init() {
+	puzzle.Register(2024, 4, func() puzzle.Solver { return &solver{} })
 }
 
-type point struct {
-	x int
-	y int
+type solver struct {
+	mapData *grid.Grid[rune]
 }
 
-func parseMap(file io.Reader) map[point]rune {
-
-	mapData := make(map[point]rune)
-
-	scanner := bufio.NewScanner(file)
-	rowIndex := 0
-	for scanner.Scan() {
-		line := scanner.Text()
-		for columnIndex, char := range line {
-			mapData[point{columnIndex, rowIndex}] = char
-		}
-		rowIndex++
-	}
-
-	return mapData
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.mapData, err = grid.ParseRunes(input)
+	return err
 }
 
-type vector point
-
-var up vector = vector{0, -1}
-var down vector = vector{0, 1}
-var left vector = vector{-1, 0}
-var right vector = vector{1, 0}
-var upLeft vector = vector{-1, -1}
-var upRight vector = vector{1, -1}
-var downLeft vector = vector{-1, 1}
-var downRight vector = vector{1, 1}
+func (s *solver) Part1() (puzzle.Answer, error) {
+	return countWords(s.mapData, []rune{'X', 'M', 'A', 'S'}), nil
+}
 
-var directions = []vector{up, down, left, right, upLeft, upRight, downLeft, downRight}
+func (s *solver) Part2() (puzzle.Answer, error) {
+	return countX(s.mapData, []rune{'M', 'A', 'S'}), nil
+}
 
-func countWords(mapData map[point]rune, searchWord []rune) int {
+func countWords(mapData *grid.Grid[rune], searchWord []rune) int {
 	wordCount := 0
 
-	for point, char := range mapData {
+	for point, char := range mapData.All() {
 		if char == searchWord[0] {
-			for _, direction := range directions {
+			for _, direction := range geometry.Directions {
 				if checkWord(mapData, point, searchWord, direction) {
 					wordCount++
 				}
@@ -75,17 +46,18 @@ func countWords(mapData map[point]rune, searchWord []rune) int {
 	return wordCount
 }
 
-func checkWord(mapData map[point]rune, start point, searchWord []rune, direction vector) bool {
+func checkWord(mapData *grid.Grid[rune], start grid.Point, searchWord []rune, direction geometry.Direction) bool {
+	step := geometry.Step[int](direction)
 	for i, char := range searchWord {
-		currentPoint := point{start.x + direction.x*i, start.y + direction.y*i}
-		if mapData[currentPoint] != char {
+		currentPoint := start.Add(step.Scale(i))
+		if c, _ := mapData.Get(currentPoint); c != char {
 			return false
 		}
 	}
 	return true
 }
 
-func countX(mapData map[point]rune, searchWord []rune) int {
+func countX(mapData *grid.Grid[rune], searchWord []rune) int {
 	xCount := 0
 	var middle int = len(searchWord) / 2 //  "Rounds" down beause division truncates to int
 	if len(searchWord) == 0 {
@@ -94,12 +66,12 @@ func countX(mapData map[point]rune, searchWord []rune) int {
 
 	// Find all the upleft and downright diagonals that contain the search word
 	// Store the location of the middle rune
-	backdiagonals := make(map[point]rune)
-	for loc, char := range mapData {
+	backdiagonals := make(map[grid.Point]rune)
+	for loc, char := range mapData.All() {
 		if char == searchWord[0] {
-			for _, direction := range []vector{upLeft, downRight} {
+			for _, direction := range []geometry.Direction{geometry.NorthWest, geometry.SouthEast} {
 				if checkWord(mapData, loc, searchWord, direction) {
-					backdiagonals[point{loc.x + direction.x*middle, loc.y + direction.y*middle}] = char
+					backdiagonals[loc.Add(geometry.Step[int](direction).Scale(middle))] = char
 				}
 			}
 		}
@@ -107,12 +79,12 @@ func countX(mapData map[point]rune, searchWord []rune) int {
 
 	// Find all of the upRight and downLeft diagonals that contain the search word
 	// Store the location of the middle rune
-	diagonals := make(map[point]rune)
-	for loc, char := range mapData {
+	diagonals := make(map[grid.Point]rune)
+	for loc, char := range mapData.All() {
 		if char == searchWord[0] {
-			for _, direction := range []vector{upRight, downLeft} {
+			for _, direction := range []geometry.Direction{geometry.NorthEast, geometry.SouthWest} {
 				if checkWord(mapData, loc, searchWord, direction) {
-					diagonals[point{loc.x + direction.x*middle, loc.y + direction.y*middle}] = char
+					diagonals[loc.Add(geometry.Step[int](direction).Scale(middle))] = char
 				}
 			}
 		}
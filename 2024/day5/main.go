@@ -1,90 +1,93 @@
-package main
+package day5
 
 import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
-	"os"
+	"slices"
 	"strconv"
 	"strings"
-)
 
-func main() {
-	var (
-		file *os.File
-		err  error
-	)
-
-	switch len(os.Args) {
-	case 1:
-		file = os.Stdin
-	case 2:
-		file, err = os.Open(os.Args[1])
-		if err != nil {
-			log.Fatalf("Error opening file: %s", os.Args[1])
-		}
-	}
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+	data "github.com/neilfenwick/advent-of-code/data_structures"
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
 
-	pageOrderingRules, pageUpdates := parseInput(file)
+func init() {
+	puzzle.Register(2024, 5, func() puzzle.Solver { return &solver{} })
+}
 
-	sumValidUpdates, sumInvalidUpdates := 0, 0
-	for _, update := range pageUpdates {
+type solver struct {
+	rules   *data.Digraph[int]
+	updates [][]int
+}
 
-		pageOrderValid := processRulesForPageUpdates(pageOrderingRules, update)
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.rules, s.updates, err = parseInput(input)
+	return err
+}
 
-		middleValue := update.pageUpdates[len(update.pageUpdates)/2]
-		if pageOrderValid {
-			sumValidUpdates += middleValue
-		} else {
-			sumInvalidUpdates += middleValue
-		}
-	}
+func (s *solver) Part1() (puzzle.Answer, error) {
+	sumValidUpdates, _, err := sumMiddlePages(s.rules, s.updates)
+	return sumValidUpdates, err
+}
 
-	fmt.Printf("Sum of all middle values of in order updates: %d\n", sumValidUpdates)
-	fmt.Printf("Sum of all middle values of out of order updates: %d\n", sumInvalidUpdates)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	_, sumInvalidUpdates, err := sumMiddlePages(s.rules, s.updates)
+	return sumInvalidUpdates, err
 }
 
-func processRulesForPageUpdates(pageOrderingRules []rule, update *pageUpdateIndex) bool {
-	isOriginalValid := true
-	for _, rule := range pageOrderingRules {
-		leftIdx, leftFound := update.index[rule.left]
-		rightIdx, rightFound := update.index[rule.right]
-		if !leftFound || !rightFound {
+// sumMiddlePages sums the middle page numbers of the updates that are already in order,
+// and separately of those that had to be re-ordered
+func sumMiddlePages(pageOrderingRules *data.Digraph[int], pageUpdates [][]int) (int, int, error) {
+	sumValidUpdates, sumInvalidUpdates := 0, 0
+	for _, update := range pageUpdates {
+		if isInOrder(pageOrderingRules, update) {
+			sumValidUpdates += update[len(update)/2]
 			continue
 		}
 
-		if leftIdx > rightIdx {
-			isOriginalValid = false
-			update.pageUpdates[leftIdx], update.pageUpdates[rightIdx] = update.pageUpdates[rightIdx], update.pageUpdates[leftIdx]
-			update.populateIndeces()
-			break
+		ordered, err := orderPages(pageOrderingRules, update)
+		if err != nil {
+			return 0, 0, err
 		}
+		sumInvalidUpdates += ordered[len(ordered)/2]
 	}
-
-	if !isOriginalValid {
-		processRulesForPageUpdates(pageOrderingRules, update)
-	}
-
-	return isOriginalValid
+	return sumValidUpdates, sumInvalidUpdates, nil
 }
 
-type rule struct {
-	left  int
-	right int
+// isInOrder reports whether no rule requires a page to come before one that is
+// earlier in the update
+func isInOrder(pageOrderingRules *data.Digraph[int], update []int) bool {
+	for i, page := range update {
+		for _, earlier := range update[:i] {
+			if _, found := pageOrderingRules.Edge(page, earlier); found {
+				return false
+			}
+		}
+	}
+	return true
 }
 
-type pageUpdateIndex struct {
-	pageUpdates []int
-	index       map[int]int
+// orderPages sorts the pages of an update by the rules that apply to them. The
+// rules as a whole have cycles, so only the rules between pages in the update
+// are used.
+func orderPages(pageOrderingRules *data.Digraph[int], update []int) ([]int, error) {
+	rules := pageOrderingRules.Subgraph(func(page int) bool {
+		return slices.Contains(update, page)
+	})
+	for _, page := range update {
+		rules.AddVertex(page) // in case no rule mentions it
+	}
+	ordered, err := rules.TopologicalSort()
+	if err != nil {
+		return nil, fmt.Errorf("ordering update %v: %w", update, err)
+	}
+	return ordered, nil
 }
 
-func parseInput(file io.Reader) ([]rule, []*pageUpdateIndex) {
-	rules := make([]rule, 0)
-	indeces := make([]*pageUpdateIndex, 0)
+func parseInput(file io.Reader) (*data.Digraph[int], [][]int, error) {
+	rules := data.NewDigraph[int]()
+	updates := make([][]int, 0)
 
 	isProcessingRulesSection := true
 	scanner := bufio.NewScanner(file)
@@ -96,28 +99,24 @@ func parseInput(file io.Reader) ([]rule, []*pageUpdateIndex) {
 		}
 
 		if isProcessingRulesSection {
-			rule := rule{}
-			fmt.Sscanf(line, "%d|%d", &rule.left, &rule.right)
-			rules = append(rules, rule)
+			var left, right int
+			if _, err := fmt.Sscanf(line, "%d|%d", &left, &right); err != nil {
+				return nil, nil, fmt.Errorf("invalid page ordering rule %q: %w", line, err)
+			}
+			rules.AddEdge(left, right, 1)
 			continue
 		}
 
-		index := &pageUpdateIndex{pageUpdates: make([]int, 0)}
+		update := make([]int, 0)
 		for _, page := range strings.Split(line, ",") {
-			pageNum, _ := strconv.Atoi(page)
-			index.pageUpdates = append(index.pageUpdates, pageNum)
+			pageNum, err := strconv.Atoi(page)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid page update %q: %w", line, err)
+			}
+			update = append(update, pageNum)
 		}
-		index.populateIndeces()
-		indeces = append(indeces, index)
+		updates = append(updates, update)
 	}
 
-	return rules, indeces
-}
-
-func (p *pageUpdateIndex) populateIndeces() {
-	index := make(map[int]int, 0)
-	for idx, pageNum := range p.pageUpdates {
-		index[pageNum] = idx
-	}
-	p.index = index
+	return rules, updates, scanner.Err()
 }
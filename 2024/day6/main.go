@@ -1,146 +1,139 @@
-package main
+package day6
 
 import (
-	"bufio"
+	"bytes"
 	"fmt"
-	"log"
-	"os"
-	"strings"
-)
+	"io"
 
-func main() {
-	file, err := os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
-	}
+	"github.com/neilfenwick/advent-of-code/cycle"
+	"github.com/neilfenwick/advent-of-code/geometry"
+	"github.com/neilfenwick/advent-of-code/grid"
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
 
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func init() {
+	puzzle.Register(2024, 6, func() puzzle.Solver { return &solver{} })
+}
 
-	grid := parseInput(file)
-	pointCount, _ := countGuardPathPointsVisited(grid)
-	fmt.Printf("Guard visited %d points\n", pointCount)
+type solver struct {
+	input []byte
+}
 
-	// I did this the brute force way by iterating over all points and adding an obstacle to each point
-	// and checking if the guard is stuck in a loop. Is there a more efficient way to do this?
-	loopObstructionCount := countLoopObstructions(grid)
-	fmt.Printf("There are %d points that cause the guard to loop\n", loopObstructionCount)
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
 }
 
-type point struct {
-	x, y int
+func (s *solver) Part1() (puzzle.Answer, error) {
+	lab, err := parseInput(bytes.NewReader(s.input))
+	if err != nil {
+		return nil, err
+	}
+	return countGuardPathPointsVisited(lab)
 }
 
-type vector point
+func (s *solver) Part2() (puzzle.Answer, error) {
+	lab, err := parseInput(bytes.NewReader(s.input))
+	if err != nil {
+		return nil, err
+	}
 
-var up = vector{x: 0, y: -1}
-var down = vector{x: 0, y: 1}
-var left = vector{x: -1, y: 0}
-var right = vector{x: 1, y: 0}
+	// I did this the brute force way by iterating over all points and adding an obstacle to each point
+	// and checking if the guard is stuck in a loop. Is there a more efficient way to do this?
+	return countLoopObstructions(lab), nil
+}
 
 type obstacleGrid struct {
-	sizeX, sizeY        int
-	obstacles           map[point]bool
-	guardStartPos       point
-	guardStartDirection vector
+	obstacles           *grid.Grid[bool]
+	guardStartPos       grid.Point
+	guardStartDirection geometry.Direction
 }
 
-func parseInput(file *os.File) *obstacleGrid {
+func parseInput(file io.Reader) (*obstacleGrid, error) {
 	var (
-		width, height  int
-		guardPos       point
-		guardDirection vector
+		guardPos       grid.Point
+		guardDirection geometry.Direction
 	)
-	obstacles := make(map[point]bool)
-
-	s := bufio.NewScanner(file)
-	for s.Scan() {
-		line := strings.TrimSpace(s.Text())
-
-		for i, c := range line {
-			switch c {
-			case '#':
-				point := point{x: i, y: height}
-				obstacles[point] = true
-			case '^':
-				guardPos = point{x: i, y: height}
-				guardDirection = up
-			}
+	obstacles, err := grid.Parse(file, func(p grid.Point, c rune) (bool, error) {
+		if c == '^' {
+			guardPos, guardDirection = p, geometry.North
 		}
-
-		width = len(line)
-		height++
+		return c == '#', nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	grid := &obstacleGrid{
-		sizeX:               width,
-		sizeY:               height,
+	lab := &obstacleGrid{
 		obstacles:           obstacles,
 		guardStartPos:       guardPos,
 		guardStartDirection: guardDirection,
 	}
 
-	return grid
+	return lab, nil
 }
 
-func countGuardPathPointsVisited(grid *obstacleGrid) (int, error) {
-	guardPos := grid.guardStartPos
-	guardDirection := grid.guardStartDirection
-	pointsVisited := make(map[point]vector, grid.sizeX*grid.sizeY)
-
-	for guardPos.x >= 0 && guardPos.x < grid.sizeX && guardPos.y >= 0 && guardPos.y < grid.sizeY {
-		if prevDirection, found := pointsVisited[guardPos]; !found {
-			pointsVisited[guardPos] = guardDirection
-		} else {
-			if prevDirection == guardDirection {
-				return 0, fmt.Errorf("guard is stuck in a loop")
-			}
-		}
+// guard is where the guard is and which way they are facing
+type guard struct {
+	pos       grid.Point
+	direction geometry.Direction
+}
 
-		nextPos := point{x: guardPos.x + guardDirection.x, y: guardPos.y + guardDirection.y}
-		if grid.obstacles[nextPos] {
-			switch guardDirection {
-			case up:
-				guardDirection = right
-			case right:
-				guardDirection = down
-			case down:
-				guardDirection = left
-			case left:
-				guardDirection = up
-			}
-		} else {
-			guardPos = nextPos
-		}
+func (lab *obstacleGrid) start() guard {
+	return guard{pos: lab.guardStartPos, direction: lab.guardStartDirection}
+}
+
+// step moves the guard forward, or turns them right when there is an obstacle in the way.
+// Once the guard has left the lab they stay where they are.
+func (lab *obstacleGrid) step(g guard) guard {
+	if !lab.obstacles.InBounds(g.pos) {
+		return g
+	}
+	nextPos := g.pos.Move(g.direction)
+	if obstacle, _ := lab.obstacles.Get(nextPos); obstacle {
+		return guard{pos: g.pos, direction: g.direction.TurnRight()}
+	}
+	return guard{pos: nextPos, direction: g.direction}
+}
+
+// guardLoops reports whether the guard walks round in a loop forever, rather than leaving the
+// lab, where the walk would end up repeating the same state outside it
+func (lab *obstacleGrid) guardLoops() bool {
+	_, repeated := cycle.Brent(lab.start(), lab.step)
+	return lab.obstacles.InBounds(repeated.pos)
+}
+
+func countGuardPathPointsVisited(lab *obstacleGrid) (int, error) {
+	if lab.guardLoops() {
+		return 0, fmt.Errorf("guard is stuck in a loop")
+	}
+
+	pointsVisited := make(map[grid.Point]bool, lab.obstacles.Width()*lab.obstacles.Height())
+	for g := lab.start(); lab.obstacles.InBounds(g.pos); g = lab.step(g) {
+		pointsVisited[g.pos] = true
 	}
 
 	return len(pointsVisited), nil
 }
 
-func countLoopObstructions(grid *obstacleGrid) int {
+func countLoopObstructions(lab *obstacleGrid) int {
 	loopCount := 0
 
-	for x := range grid.sizeX {
-		for y := range grid.sizeY {
-			// Skip if there is already an obstacle at this point, or it is the guard start position,
-			// or directly in front of the guard start position
-			if grid.obstacles[point{x: x, y: y}] ||
-				(x == grid.guardStartPos.x && y == grid.guardStartPos.y) ||
-				(x == grid.guardStartPos.x+grid.guardStartDirection.x && y == grid.guardStartPos.y+grid.guardStartDirection.y) {
-				continue
-			}
-
-			// add an obstacle and check if the guard is stuck in a loop
-			grid.obstacles[point{x: x, y: y}] = true
-			if _, err := countGuardPathPointsVisited(grid); err != nil {
-				loopCount++
-			}
-
-			// remove the obstacle because this same instance of the map is used for all iterations
-			delete(grid.obstacles, point{x: x, y: y})
+	for p, obstacle := range lab.obstacles.All() {
+		// Skip if there is already an obstacle at this point, or it is the guard start position,
+		// or directly in front of the guard start position
+		if obstacle || p == lab.guardStartPos || p == lab.guardStartPos.Move(lab.guardStartDirection) {
+			continue
+		}
 
+		// add an obstacle and check if the guard is stuck in a loop
+		lab.obstacles.Set(p, true)
+		if lab.guardLoops() {
+			loopCount++
 		}
+
+		// remove the obstacle because this same instance of the map is used for all iterations
+		lab.obstacles.Set(p, false)
 	}
 
 	return loopCount
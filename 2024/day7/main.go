@@ -1,43 +1,44 @@
-package main
+package day7
 
 import (
 	"bufio"
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"strings"
+
+	"github.com/neilfenwick/advent-of-code/memo"
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	file, err := os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
-	}
+func init() {
+	puzzle.Register(2024, 7, func() puzzle.Solver { return &solver{} })
+}
 
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+type solver struct {
+	equations []equation
+}
 
-	equations := readInput(file)
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.equations, err = readInput(input)
+	return err
+}
 
-	// Part 1
+func (s *solver) Part1() (puzzle.Answer, error) {
 	var total uint64
-	matches := findMatchingEquations(equations, []func(uint64, uint64) uint64{add, multiply})
+	matches := findMatchingEquations(s.equations, []func(uint64, uint64) uint64{add, multiply})
 	for _, eq := range matches {
 		total += eq.result
 	}
+	return total, nil
+}
 
-	fmt.Printf("Part1: Total of matching equations: %d\n", total)
-
-	// Part 2
-	total = 0
-
-	matchesPt2 := findMatchingEquations(equations, []func(uint64, uint64) uint64{add, multiply, concat})
+func (s *solver) Part2() (puzzle.Answer, error) {
+	var total uint64
+	matchesPt2 := findMatchingEquations(s.equations, []func(uint64, uint64) uint64{add, multiply, concat})
 	for _, eq := range matchesPt2 {
 		total += eq.result
 	}
-
-	fmt.Printf("Part2: Total of matching equations: %d\n", total)
+	return total, nil
 }
 
 type equation struct {
@@ -73,68 +74,54 @@ func concat(a, b uint64) uint64 {
 	return a*multiplier + b
 }
 
-/*
-apply is a currying function
-
-takes a first argument of an aggregating function that will return an int, and a second argument of another int,
-with a third argument of a function to be applied to the result of the first two arguments. It returns a wrapper
-function that will return the result of the third function applied to the result of the f(a(), b).
-*/
-func apply(a func() uint64, b uint64, f func(uint64, uint64) uint64) func() uint64 {
-	return func() uint64 {
-		return f(a(), b)
-	}
-}
-
-func readInput(file *os.File) []equation {
+func readInput(file io.Reader) ([]equation, error) {
 	equations := make([]equation, 0, 1000)
 	s := bufio.NewScanner(file)
 	for s.Scan() {
 		line := strings.TrimSpace(s.Text())
-		equations = append(equations, parseEquation(line))
+		eq, err := parseEquation(line)
+		if err != nil {
+			return nil, err
+		}
+		equations = append(equations, eq)
 	}
-	return equations
+	return equations, s.Err()
 }
 
-func parseEquation(line string) equation {
+func parseEquation(line string) (equation, error) {
 	eq := equation{}
 
 	parts := strings.SplitN(line, ":", 2)
-	if len(parts) != 2 {
-		log.Fatalf("Invalid equation: %s", line)
+	operands := strings.Fields(parts[len(parts)-1])
+	if len(parts) != 2 || len(operands) == 0 {
+		return eq, fmt.Errorf("invalid equation: %s", line)
 	}
 
 	// Parse the result part
 	fmt.Sscanf(strings.TrimSpace(parts[0]), "%d", &eq.result)
 
 	// Parse the operands part
-	operands := strings.Fields(strings.TrimSpace(parts[1]))
 	for _, op := range operands {
 		var operand uint64
 		fmt.Sscanf(op, "%d", &operand)
 		eq.operands = append(eq.operands, operand)
 	}
 
-	return eq
-}
-
-type treeNode struct {
-	value    func() uint64
-	children []*treeNode
+	return eq, nil
 }
 
-func (currentNode *treeNode) appendChild(value *treeNode) {
-	currentNode.children = append(currentNode.children, value)
+// partialResult is how far through an equation the operators have been applied,
+// with the total of the operands so far
+type partialResult struct {
+	next  int
+	total uint64
 }
 
 func findMatchingEquations(equations []equation, operators []func(uint64, uint64) uint64) []equation {
 	results := make([]equation, 0, len(equations))
 
 	for _, eq := range equations {
-		treeRoot := treeNode{value: func() uint64 { return eq.operands[0] }}
-		treeRoot.appendCalculationBranches(eq.operands[1:], operators)
-
-		if depthFirstSearchMatchingEquation(&treeRoot, eq.result) {
+		if canMatchEquation(eq, operators) {
 			results = append(results, eq)
 		}
 	}
@@ -142,32 +129,20 @@ func findMatchingEquations(equations []equation, operators []func(uint64, uint64
 	return results
 }
 
-func (currentNode *treeNode) appendCalculationBranches(operands []uint64, operators []func(uint64, uint64) uint64) {
-	if len(operands) == 0 {
-		return
-	}
-
-	for _, operator := range operators {
-		// Create a new apply() function for the result() function
-		result := apply(currentNode.value, operands[0], operator)
-		node := &treeNode{value: result}
-
-		currentNode.appendChild(node)
-
-		node.appendCalculationBranches(operands[1:], operators)
-	}
-}
-
-func depthFirstSearchMatchingEquation(currentNode *treeNode, result uint64) bool {
-	if len(currentNode.children) == 0 {
-		// We are at the leaf node
-		return currentNode.value() == result
-	}
-
-	for _, child := range currentNode.children {
-		if depthFirstSearchMatchingEquation(child, result) {
-			return true
+// canMatchEquation searches depth first for operators that make the operands
+// add up to the result. Different operators can reach the same total part way
+// through, so the search from each partial result is memoized.
+func canMatchEquation(eq equation, operators []func(uint64, uint64) uint64) bool {
+	search := memo.New(func(search func(partialResult) bool, p partialResult) bool {
+		if p.next == len(eq.operands) {
+			return p.total == eq.result
 		}
-	}
-	return false
+		for _, operator := range operators {
+			if search(partialResult{next: p.next + 1, total: operator(p.total, eq.operands[p.next])}) {
+				return true
+			}
+		}
+		return false
+	})
+	return search.Call(partialResult{next: 1, total: eq.operands[0]})
 }
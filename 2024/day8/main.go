@@ -1,38 +1,40 @@
-package main
+package day8
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
-	"log"
-	"os"
 	"unicode"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
 )
 
-func main() {
-	file, err := os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
-	}
+func init() {
+	puzzle.Register(2024, 8, func() puzzle.Solver { return &solver{} })
+}
+
+type solver struct {
+	input []byte
+}
 
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
+func (s *solver) Parse(input io.Reader) (err error) {
+	s.input, err = io.ReadAll(input)
+	return err
+}
 
-	result := processFile(file)
+func (s *solver) Part1() (puzzle.Answer, error) {
+	result := processFile(bytes.NewReader(s.input))
 	result.populateAntinodeMapPart1()
 	result.trimMapToBounds(result.size.x, result.size.y)
-	antiNodeCount := result.countUniqueAntinodes()
-
-	fmt.Printf("Number of anti-nodes: %d\n", antiNodeCount)
+	return result.countUniqueAntinodes(), nil
+}
 
-	file.Seek(0, 0)
-	resultPart2 := processFile(file)
+func (s *solver) Part2() (puzzle.Answer, error) {
+	resultPart2 := processFile(bytes.NewReader(s.input))
 	resultPart2.populateAntinodeMapPart2()
-	result.trimMapToBounds(result.size.x, result.size.y)
-	antiNodeCountPart2 := resultPart2.countUniqueAntinodes()
-
-	fmt.Printf("Number of anti-nodes part2: %d\n", antiNodeCountPart2)
+	resultPart2.trimMapToBounds(resultPart2.size.x, resultPart2.size.y)
+	return resultPart2.countUniqueAntinodes(), nil
 }
 
 func processFile(file io.Reader) *antiNodeMap {
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+// The phases of solving a puzzle that are timed separately.
+const (
+	phaseParse = "parse"
+	phasePart1 = "part1"
+	phasePart2 = "part2"
+)
+
+// benchResult is the cost of one phase of solving a puzzle, averaged over the
+// runs.
+type benchResult struct {
+	Year        int    `json:"year"`
+	Day         int    `json:"day"`
+	Phase       string `json:"phase"`
+	Runs        int    `json:"runs"`
+	NsPerOp     int64  `json:"ns_per_op"`
+	AllocsPerOp uint64 `json:"allocs_per_op"`
+	BytesPerOp  uint64 `json:"bytes_per_op"`
+}
+
+type benchKey struct {
+	year, day int
+	phase     string
+}
+
+var benchCSVHeader = []string{"year", "day", "phase", "runs", "ns_per_op", "allocs_per_op", "bytes_per_op"}
+
+func benchCommand(args []string) error {
+	flags := flag.NewFlagSet("bench", flag.ExitOnError)
+	year := flags.Int("year", 0, "only benchmark puzzles from this `year`")
+	day := flags.Int("day", 0, "only benchmark puzzles from this `day`")
+	runs := flags.Int("runs", 5, "`number` of times to run each phase")
+	inputDir := flags.String("inputs", ".", "`directory` holding YEAR/dayN/input.txt puzzle inputs")
+	download := flags.Bool("download", false, "download inputs that are not found locally or in the cache")
+	outPath := flags.String("o", "", "save the results to a .json or .csv `file`")
+	baselinePath := flags.String("baseline", "", "compare the results with those saved in a .json or .csv `file`")
+	threshold := flags.Float64("threshold", 10, "`percent` increase over the baseline that counts as a regression")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: aoc bench [-year Y] [-day D] [-runs N] [-o file] [-baseline file [-threshold percent]]\n")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if *runs < 1 {
+		return fmt.Errorf("cannot run each phase %d times, expected at least 1", *runs)
+	}
+
+	var baseline map[benchKey]benchResult
+	if *baselinePath != "" {
+		results, err := readBenchResults(*baselinePath)
+		if err != nil {
+			return err
+		}
+		baseline = make(map[benchKey]benchResult, len(results))
+		for _, r := range results {
+			baseline[benchKey{r.Year, r.Day, r.Phase}] = r
+		}
+	}
+
+	open := savedInputs(*inputDir, *download)
+
+	var results []benchResult
+	for _, p := range selectPuzzles(*year, *day) {
+		r, err := benchPuzzle(p, open, *runs)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%d day %d: %w", p.Year, p.Day, err)
+		}
+		results = append(results, r...)
+	}
+
+	regressions := printBenchResults(os.Stdout, results, baseline, *threshold)
+
+	if *outPath != "" {
+		if err := writeBenchResults(*outPath, results); err != nil {
+			return err
+		}
+	}
+	if regressions > 0 {
+		return fmt.Errorf("bench: %d regressed by more than %g%%", regressions, *threshold)
+	}
+	return nil
+}
+
+// benchPuzzle times parsing the input, and then each part that is implemented.
+func benchPuzzle(p puzzle.Puzzle, open inputOpener, runs int) ([]benchResult, error) {
+	f, err := open(p.Year, p.Day)
+	if err != nil {
+		return nil, err
+	}
+	input, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var solver puzzle.Solver
+	var parseErr error
+	results := []benchResult{measure(p, phaseParse, runs, func() {
+		solver = p.New()
+		parseErr = solver.Parse(bytes.NewReader(input))
+	})}
+	if parseErr != nil {
+		return nil, fmt.Errorf("parsing input: %w", parseErr)
+	}
+
+	for part, phase := range []string{phasePart1, phasePart2} {
+		if _, err := puzzle.SolvePart(solver, part+1); errors.Is(err, puzzle.ErrNotImplemented) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("part %d: %w", part+1, err)
+		}
+		results = append(results, measure(p, phase, runs, func() {
+			_, _ = puzzle.SolvePart(solver, part+1)
+		}))
+	}
+	return results, nil
+}
+
+// measure runs f the given number of times, and returns the average time and
+// memory allocated per run.
+func measure(p puzzle.Puzzle, phase string, runs int, f func()) benchResult {
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	for i := 0; i < runs; i++ {
+		f()
+	}
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	return benchResult{
+		Year:        p.Year,
+		Day:         p.Day,
+		Phase:       phase,
+		Runs:        runs,
+		NsPerOp:     elapsed.Nanoseconds() / int64(runs),
+		AllocsPerOp: (after.Mallocs - before.Mallocs) / uint64(runs),
+		BytesPerOp:  (after.TotalAlloc - before.TotalAlloc) / uint64(runs),
+	}
+}
+
+// printBenchResults writes the results as a table. When there is a baseline,
+// each result is compared with it, and the number of regressions, where the
+// time or allocations grew by more than threshold percent, is returned.
+func printBenchResults(out io.Writer, results []benchResult, baseline map[benchKey]benchResult, threshold float64) int {
+	regressions := 0
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if baseline == nil {
+		fmt.Fprintln(w, "YEAR\tDAY\tPHASE\tTIME/OP\tALLOCS/OP\tBYTES/OP")
+	} else {
+		fmt.Fprintln(w, "YEAR\tDAY\tPHASE\tTIME/OP\tALLOCS/OP\tBYTES/OP\tBASELINE\tTIME\tALLOCS\tSTATUS")
+	}
+
+	for _, r := range results {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%v\t%d\t%d", r.Year, r.Day, r.Phase, time.Duration(r.NsPerOp), r.AllocsPerOp, r.BytesPerOp)
+		if baseline != nil {
+			base, found := baseline[benchKey{r.Year, r.Day, r.Phase}]
+			if !found {
+				fmt.Fprintf(w, "\t-\t-\t-\tnew")
+			} else {
+				timeChange := percentChange(float64(base.NsPerOp), float64(r.NsPerOp))
+				allocsChange := percentChange(float64(base.AllocsPerOp), float64(r.AllocsPerOp))
+				status := "ok"
+				if timeChange > threshold || allocsChange > threshold {
+					status = "REGRESSION"
+					regressions++
+				}
+				fmt.Fprintf(w, "\t%v\t%+.1f%%\t%+.1f%%\t%s", time.Duration(base.NsPerOp), timeChange, allocsChange, status)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	w.Flush()
+
+	return regressions
+}
+
+// percentChange returns how much larger the value is than the baseline, as a
+// percentage of the baseline.
+func percentChange(baseline, value float64) float64 {
+	if baseline == 0 {
+		if value == 0 {
+			return 0
+		}
+		return 100
+	}
+	return (value - baseline) / baseline * 100
+}
+
+// writeBenchResults saves the results as CSV for a .csv file, or as JSON.
+func writeBenchResults(path string, results []benchResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if filepath.Ext(path) == ".csv" {
+		err = writeBenchCSV(f, results)
+	} else {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(results)
+	}
+
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func writeBenchCSV(w io.Writer, results []benchResult) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write(benchCSVHeader)
+	for _, r := range results {
+		_ = cw.Write([]string{
+			strconv.Itoa(r.Year),
+			strconv.Itoa(r.Day),
+			r.Phase,
+			strconv.Itoa(r.Runs),
+			strconv.FormatInt(r.NsPerOp, 10),
+			strconv.FormatUint(r.AllocsPerOp, 10),
+			strconv.FormatUint(r.BytesPerOp, 10),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// readBenchResults loads results saved by writeBenchResults.
+func readBenchResults(path string) ([]benchResult, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var results []benchResult
+	if filepath.Ext(path) == ".csv" {
+		results, err = readBenchCSV(f)
+	} else {
+		err = json.NewDecoder(f).Decode(&results)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline %s: %w", path, err)
+	}
+	return results, nil
+}
+
+func readBenchCSV(r io.Reader) ([]benchResult, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(benchCSVHeader)
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []benchResult
+	for i, record := range records {
+		if i == 0 {
+			continue // header
+		}
+		var r benchResult
+		var errs [6]error
+		r.Year, errs[0] = strconv.Atoi(record[0])
+		r.Day, errs[1] = strconv.Atoi(record[1])
+		r.Phase = record[2]
+		r.Runs, errs[2] = strconv.Atoi(record[3])
+		r.NsPerOp, errs[3] = strconv.ParseInt(record[4], 10, 64)
+		r.AllocsPerOp, errs[4] = strconv.ParseUint(record[5], 10, 64)
+		r.BytesPerOp, errs[5] = strconv.ParseUint(record[6], 10, 64)
+		if err := errors.Join(errs[:]...); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+func TestBenchPuzzle(t *testing.T) {
+	p := puzzle.Puzzle{Year: 1915, Day: 1, New: func() puzzle.Solver { return &lineCounter{} }}
+	open := func(year, day int) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("a\nb\n")), nil
+	}
+
+	results, err := benchPuzzle(p, open, 3)
+	if err != nil {
+		t.Fatalf("benchPuzzle() error = %v", err)
+	}
+
+	var phases []string
+	for _, r := range results {
+		phases = append(phases, r.Phase)
+		if r.Runs != 3 {
+			t.Errorf("%s ran %d times, want 3", r.Phase, r.Runs)
+		}
+	}
+	if want := []string{phaseParse, phasePart1}; !reflect.DeepEqual(phases, want) {
+		t.Errorf("benchPuzzle() phases = %v, want %v without the unimplemented part 2", phases, want)
+	}
+}
+
+func TestWriteBenchResultsThenRead(t *testing.T) {
+	results := []benchResult{
+		{Year: 2024, Day: 6, Phase: phaseParse, Runs: 5, NsPerOp: 1200, AllocsPerOp: 30, BytesPerOp: 4096},
+		{Year: 2024, Day: 6, Phase: phasePart2, Runs: 5, NsPerOp: 9_000_000, AllocsPerOp: 1000, BytesPerOp: 65536},
+	}
+
+	for _, name := range []string{"bench.json", "bench.csv"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			if err := writeBenchResults(path, results); err != nil {
+				t.Fatalf("writeBenchResults() error = %v", err)
+			}
+			got, err := readBenchResults(path)
+			if err != nil {
+				t.Fatalf("readBenchResults() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, results) {
+				t.Errorf("readBenchResults() = %+v, want %+v", got, results)
+			}
+		})
+	}
+}
+
+func TestPrintBenchResults_Regressions(t *testing.T) {
+	baseline := map[benchKey]benchResult{
+		{2024, 6, phasePart1}: {NsPerOp: 1000, AllocsPerOp: 10},
+		{2024, 6, phasePart2}: {NsPerOp: 1000, AllocsPerOp: 10},
+	}
+	results := []benchResult{
+		{Year: 2024, Day: 6, Phase: phaseParse, NsPerOp: 5000, AllocsPerOp: 50},
+		{Year: 2024, Day: 6, Phase: phasePart1, NsPerOp: 1050, AllocsPerOp: 10},
+		{Year: 2024, Day: 6, Phase: phasePart2, NsPerOp: 900, AllocsPerOp: 20},
+	}
+
+	var out strings.Builder
+	if got := printBenchResults(&out, results, baseline, 10); got != 1 {
+		t.Errorf("printBenchResults() = %d regressions, want 1\n%s", got, out.String())
+	}
+	if got := printBenchResults(io.Discard, results, nil, 10); got != 0 {
+		t.Errorf("printBenchResults() without a baseline = %d regressions, want 0", got)
+	}
+}
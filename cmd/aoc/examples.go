@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+var (
+	articlePattern  = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	preCodePattern  = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	emphasisPattern = regexp.MustCompile(`(?s)<code><em>(.*?)</em></code>|<em><code>(.*?)</code></em>`)
+	tagPattern      = regexp.MustCompile(`<[^>]*>`)
+)
+
+// pagePart is what is extracted from the description of one part of a puzzle.
+type pagePart struct {
+	examples []string
+	answer   string
+}
+
+// exampleCase is one row of the generated table test.
+type exampleCase struct {
+	Name  string
+	Input string
+	Part  int
+	Want  string
+}
+
+func examplesCommand(args []string) error {
+	flags := flag.NewFlagSet("examples", flag.ExitOnError)
+	year := flags.Int("year", 0, "puzzle `year`, e.g. 2024")
+	day := flags.Int("day", 0, "puzzle `day`, 1-25")
+	root := flags.String("root", ".", "root `directory` of the repository")
+	answersPath := flags.String("answers", "answers.json", "`file` of known answers to record the example answers in")
+	force := flags.Bool("force", false, "overwrite existing examples and tests")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: aoc examples -year Y -day D [-answers file] [-force] saved-puzzle-page.html\n")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("expected the saved puzzle page")
+	}
+	page, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(*root, fmt.Sprint(*year), fmt.Sprintf("day%d", *day))
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("no package for %d day %d at %s, create it with aoc new first", *year, *day, dir)
+	}
+
+	answers, err := puzzle.ReadAnswers(*answersPath)
+	if err != nil {
+		return err
+	}
+
+	created, err := writeExamples(dir, scaffold{Year: *year, Day: *day}, parsePuzzlePage(string(page)), answers, *force)
+	for _, path := range created {
+		fmt.Println("created", path)
+	}
+	if err != nil {
+		return err
+	}
+	return answers.WriteFile(*answersPath)
+}
+
+// parsePuzzlePage extracts the example blocks and the emphasized example answer
+// from each part of a saved puzzle description. The answer is taken to be the
+// last emphasized code in the part, which is how the puzzles state them.
+func parsePuzzlePage(page string) []pagePart {
+	articles := articlePattern.FindAllStringSubmatch(page, -1)
+	if len(articles) == 0 {
+		articles = [][]string{{page, page}}
+	}
+
+	var parts []pagePart
+	for _, article := range articles {
+		var part pagePart
+		for _, block := range preCodePattern.FindAllStringSubmatch(article[1], -1) {
+			part.examples = append(part.examples, pageText(block[1]))
+		}
+		if answers := emphasisPattern.FindAllStringSubmatch(article[1], -1); len(answers) > 0 {
+			last := answers[len(answers)-1]
+			part.answer = strings.TrimSpace(pageText(last[1] + last[2]))
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// pageText removes the markup from a fragment of the page.
+func pageText(fragment string) string {
+	return html.UnescapeString(tagPattern.ReplaceAllString(fragment, ""))
+}
+
+// writeExamples writes each distinct example into testdata, and a table test
+// that checks each answer against the first example of its part. A part with
+// no example of its own is checked against the example of the part before it.
+// Each answer is also recorded in answers, for verify and watch. Existing files
+// are only written over when force is set, apart from the empty example left by
+// aoc new.
+func writeExamples(dir string, s scaffold, parts []pagePart, answers *puzzle.Answers, force bool) ([]string, error) {
+	files := make(map[string]string)
+	var order []string
+	var cases []exampleCase
+
+	seen := make(map[string]string)
+	var input string
+	for i, part := range parts {
+		for _, example := range part.examples {
+			if _, found := seen[example]; found {
+				continue
+			}
+			name := "example.txt"
+			if len(seen) > 0 {
+				name = fmt.Sprintf("example%d.txt", len(seen)+1)
+			}
+			path := filepath.Join("testdata", name)
+			seen[example] = path
+			files[path] = example
+			order = append(order, path)
+		}
+		if len(part.examples) > 0 {
+			input = seen[part.examples[0]]
+		}
+
+		if part.answer != "" && input != "" {
+			cases = append(cases, exampleCase{
+				Name:  fmt.Sprintf("Example part %d", i+1),
+				Input: filepath.ToSlash(input),
+				Part:  i + 1,
+				Want:  part.answer,
+			})
+		}
+	}
+	if len(order) == 0 {
+		return nil, errors.New("no examples found in the puzzle page")
+	}
+
+	var test bytes.Buffer
+	data := struct {
+		scaffold
+		Cases []exampleCase
+	}{s, cases}
+	if err := templates.ExecuteTemplate(&test, "examples_test.go.tmpl", data); err != nil {
+		return nil, err
+	}
+	files["examples_test.go"] = test.String()
+	order = append(order, "examples_test.go")
+
+	for _, name := range order {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); !force && info != nil && info.Size() > 0 {
+			return nil, fmt.Errorf("refusing to overwrite %s", path)
+		} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	var created []string
+	for _, name := range order {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return created, err
+		}
+		if err := os.WriteFile(path, []byte(files[name]), 0o644); err != nil {
+			return created, err
+		}
+		created = append(created, path)
+	}
+	for _, c := range cases {
+		answers.SetExample(s.Year, s.Day, c.Part, c.Input, c.Want)
+	}
+	return created, nil
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+func TestParsePuzzlePage(t *testing.T) {
+	page, err := os.ReadFile(filepath.Join("testdata", "puzzle.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []pagePart{
+		{examples: []string{"3\n<4>\n5\n", "7\n"}, answer: "16"},
+		{examples: []string{"3\n<4>\n5\n"}, answer: "3"},
+	}
+	if got := parsePuzzlePage(string(page)); !reflect.DeepEqual(got, want) {
+		t.Errorf("parsePuzzlePage() = %#v, want %#v", got, want)
+	}
+}
+
+func TestWriteExamples(t *testing.T) {
+	dir := t.TempDir()
+	s := scaffold{Year: 1915, Day: 1}
+	answers := puzzle.NewAnswers()
+	parts := []pagePart{
+		{examples: []string{"3\n<4>\n5\n", "7\n"}, answer: "16"},
+		{examples: []string{"3\n<4>\n5\n"}, answer: "3"},
+	}
+
+	// aoc new leaves an empty example to be filled in
+	example := filepath.Join(dir, "testdata", "example.txt")
+	if err := os.MkdirAll(filepath.Dir(example), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(example, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := writeExamples(dir, s, parts, answers, false); err != nil {
+		t.Fatalf("writeExamples() error = %v", err)
+	}
+
+	if got := readFile(t, example); got != "3\n<4>\n5\n" {
+		t.Errorf("example.txt = %q", got)
+	}
+	if got := readFile(t, filepath.Join(dir, "testdata", "example2.txt")); got != "7\n" {
+		t.Errorf("example2.txt = %q", got)
+	}
+	test := readFile(t, filepath.Join(dir, "examples_test.go"))
+	for _, want := range []string{
+		"puzzle.Lookup(1915, 1)",
+		`{name: "Example part 1", input: "testdata/example.txt", part: 1, want: "16"},`,
+		`{name: "Example part 2", input: "testdata/example.txt", part: 2, want: "3"},`,
+	} {
+		if !strings.Contains(test, want) {
+			t.Errorf("examples_test.go does not contain %s:\n%s", want, test)
+		}
+	}
+	wantAnswers := []puzzle.Example{
+		{Input: "testdata/example.txt", Part: 1, Answer: "16"},
+		{Input: "testdata/example.txt", Part: 2, Answer: "3"},
+	}
+	if got := answers.Examples(1915, 1); !reflect.DeepEqual(got, wantAnswers) {
+		t.Errorf("recorded examples = %v, want %v", got, wantAnswers)
+	}
+
+	if _, err := writeExamples(dir, s, parts, answers, false); err == nil {
+		t.Error("Expected writeExamples to refuse to overwrite the examples")
+	}
+	if _, err := writeExamples(dir, s, parts, answers, true); err != nil {
+		t.Errorf("writeExamples() with force error = %v", err)
+	}
+}
+
+func TestWriteExamplesUsesEachPartsOwnExample(t *testing.T) {
+	page, err := os.ReadFile(filepath.Join("testdata", "puzzle_part2.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	answers := puzzle.NewAnswers()
+
+	if _, err := writeExamples(dir, scaffold{Year: 1915, Day: 2}, parsePuzzlePage(string(page)), answers, false); err != nil {
+		t.Fatalf("writeExamples() error = %v", err)
+	}
+
+	if got := readFile(t, filepath.Join(dir, "testdata", "example2.txt")); got != "1\nx\n2\n3\n" {
+		t.Errorf("example2.txt = %q", got)
+	}
+	test := readFile(t, filepath.Join(dir, "examples_test.go"))
+	for _, want := range []string{
+		`{name: "Example part 1", input: "testdata/example.txt", part: 1, want: "6"},`,
+		`{name: "Example part 2", input: "testdata/example2.txt", part: 2, want: "5"},`,
+	} {
+		if !strings.Contains(test, want) {
+			t.Errorf("examples_test.go does not contain %s:\n%s", want, test)
+		}
+	}
+}
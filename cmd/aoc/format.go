@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// The output formats of aoc run.
+const (
+	formatText     = "text"
+	formatJSON     = "json"
+	formatMarkdown = "markdown"
+)
+
+var formats = []string{formatText, formatJSON, formatMarkdown}
+
+// runResult is the outcome of solving one part of a puzzle.
+type runResult struct {
+	Year        int            `json:"year"`
+	Day         int            `json:"day"`
+	Part        int            `json:"part"`
+	Answer      any            `json:"answer"`
+	AnswerType  string         `json:"answer_type,omitempty"`
+	ParseNs     int64          `json:"parse_ns"`
+	SolveNs     int64          `json:"solve_ns"`
+	Diagnostics map[string]any `json:"diagnostics,omitempty"`
+	Error       string         `json:"error,omitempty"`
+}
+
+// newRunResult records the answer to a part, along with its type and how long
+// parsing and solving took.
+func newRunResult(year, day, part int, answer any, parse, solve time.Duration) runResult {
+	r := runResult{Year: year, Day: day, Part: part, Answer: answer, ParseNs: parse.Nanoseconds(), SolveNs: solve.Nanoseconds()}
+	if answer != nil {
+		r.AnswerType = fmt.Sprintf("%T", answer)
+	}
+	return r
+}
+
+// printRunResults writes the results in the given format.
+func printRunResults(out io.Writer, format string, results []runResult) error {
+	switch format {
+	case formatText:
+		var diagnostics map[string]any
+		for _, r := range results {
+			if r.Error != "" {
+				fmt.Fprintf(out, "Part %d: %s\n", r.Part, r.Error)
+				continue
+			}
+			fmt.Fprintf(out, "Part %d: %v\n", r.Part, r.Answer)
+			diagnostics = r.Diagnostics
+		}
+		// the diagnostics cover every part solved so far, so are only shown once
+		for _, name := range slices.Sorted(maps.Keys(diagnostics)) {
+			fmt.Fprintf(out, "%s: %v\n", name, diagnostics[name])
+		}
+		return nil
+	case formatJSON:
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	case formatMarkdown:
+		fmt.Fprintln(out, "| Year | Day | Part | Answer | Type | Parse | Solve | Diagnostics |")
+		fmt.Fprintln(out, "| ---: | --: | ---: | ------ | ---- | ----: | ----: | ----------- |")
+		for _, r := range results {
+			answer := fmt.Sprint(r.Answer)
+			if r.Error != "" {
+				answer = r.Error
+			}
+			var diagnostics []string
+			for _, name := range slices.Sorted(maps.Keys(r.Diagnostics)) {
+				diagnostics = append(diagnostics, fmt.Sprintf("%s=%v", name, r.Diagnostics[name]))
+			}
+			fmt.Fprintf(out, "| %d | %d | %d | %s | %s | %v | %v | %s |\n",
+				r.Year, r.Day, r.Part, markdownCell(answer), r.AnswerType,
+				time.Duration(r.ParseNs), time.Duration(r.SolveNs), markdownCell(strings.Join(diagnostics, ", ")))
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown format %q, expected one of: %s", format, strings.Join(formats, ", "))
+}
+
+// markdownCell escapes text to fit in one cell of a table.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
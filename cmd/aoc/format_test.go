@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrintRunResults(t *testing.T) {
+	part1 := newRunResult(1915, 1, 1, 42, time.Millisecond, 2*time.Millisecond)
+	part1.Diagnostics = map[string]any{"lines": 3}
+	part2 := newRunResult(1915, 1, 2, nil, time.Millisecond, 0)
+	part2.Error = "not implemented"
+	results := []runResult{part1, part2}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: formatText, want: "Part 1: 42\nPart 2: not implemented\nlines: 3\n"},
+		{format: formatMarkdown, want: "| 1915 | 1 | 1 | 42 | int | 1ms | 2ms | lines=3 |\n| 1915 | 1 | 2 | not implemented |  | 1ms | 0s |  |\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := printRunResults(&out, tt.format, results); err != nil {
+				t.Fatalf("printRunResults() error = %v", err)
+			}
+			if !strings.HasSuffix(out.String(), tt.want) {
+				t.Errorf("printRunResults() = %q, want it to end with %q", out.String(), tt.want)
+			}
+		})
+	}
+
+	t.Run(formatJSON, func(t *testing.T) {
+		var out bytes.Buffer
+		if err := printRunResults(&out, formatJSON, results); err != nil {
+			t.Fatalf("printRunResults() error = %v", err)
+		}
+		var got []map[string]any
+		if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 || got[0]["answer"] != 42.0 || got[0]["answer_type"] != "int" ||
+			got[0]["solve_ns"] != 2e6 || got[1]["error"] != "not implemented" {
+			t.Errorf("printRunResults() = %s", out.String())
+		}
+	})
+
+	if err := printRunResults(&bytes.Buffer{}, "xml", results); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/neilfenwick/advent-of-code/input"
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+// inputOpener opens the puzzle input for a year and day.
+type inputOpener func(year, day int) (io.ReadCloser, error)
+
+// openInput opens the puzzle input file, stdin for "-", or the downloaded input
+// for the year and day when no path is given.
+func openInput(path string, year, day int) (io.ReadCloser, error) {
+	switch path {
+	case "-":
+		return io.NopCloser(os.Stdin), nil
+	case "":
+		client, err := input.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		return client.Open(year, day)
+	}
+	return os.Open(path)
+}
+
+// savedInputs opens the inputs kept at YEAR/dayN/input.txt under dir, and then
+// those in the download cache. When download is set, inputs that are not found
+// are downloaded, otherwise the error wraps fs.ErrNotExist. The download client
+// is only set up for an input that is not under dir, so that its errors, such
+// as a missing session token, only matter when there is an input to download.
+func savedInputs(dir string, download bool) inputOpener {
+	newClient := sync.OnceValues(input.NewClient)
+	return func(year, day int) (io.ReadCloser, error) {
+		f, err := os.Open(inputPath(dir, year, day))
+		if !errors.Is(err, fs.ErrNotExist) {
+			return f, err
+		}
+		client, clientErr := newClient()
+		switch {
+		case clientErr == nil && download:
+			return client.Open(year, day)
+		case clientErr == nil:
+			return client.Cached(year, day)
+		case download:
+			return nil, clientErr
+		}
+		return nil, err
+	}
+}
+
+// inputPath returns where the puzzle input for a year and day is kept, e.g.
+// 2024/day7/input.txt
+func inputPath(dir string, year, day int) string {
+	return filepath.Join(dayPath(dir, year, day), "input.txt")
+}
+
+// dayPath returns the directory of a year and day under dir, e.g. 2024/day7
+func dayPath(dir string, year, day int) string {
+	return filepath.Join(dir, fmt.Sprint(year), fmt.Sprintf("day%d", day))
+}
+
+// selectPuzzles returns the registered puzzles for the year and day, where 0
+// selects every year or day.
+func selectPuzzles(year, day int) []puzzle.Puzzle {
+	var puzzles []puzzle.Puzzle
+	for _, p := range puzzle.All() {
+		if (year == 0 || p.Year == year) && (day == 0 || p.Day == day) {
+			puzzles = append(puzzles, p)
+		}
+	}
+	return puzzles
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSavedInputs_LocalInputsNeedNoClient(t *testing.T) {
+	// with no cache directory, the download client cannot be set up
+	t.Setenv("AOC_CACHE_DIR", "")
+	t.Setenv("XDG_CACHE_HOME", "")
+	t.Setenv("HOME", "")
+
+	dir := t.TempDir()
+	path := inputPath(dir, 1915, 1)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := savedInputs(dir, false)(1915, 1)
+	if err != nil {
+		t.Fatalf("open local input error = %v", err)
+	}
+	b, _ := io.ReadAll(f)
+	f.Close()
+	if string(b) != "a\n" {
+		t.Errorf("open local input = %q, want %q", b, "a\n")
+	}
+
+	if _, err := savedInputs(dir, false)(1915, 2); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("open missing input error = %v, want %v", err, fs.ErrNotExist)
+	}
+	if _, err := savedInputs(dir, true)(1915, 2); err == nil || errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("download missing input error = %v, want the client error", err)
+	}
+}
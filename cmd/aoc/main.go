@@ -0,0 +1,187 @@
+// Command aoc runs the Advent of Code solution for any registered year and day.
+//
+// Usage:
+//
+//	aoc run -year 2024 -day 7 [-part 2] [-param key=value] [-format json] [input.txt | -]
+//	aoc verify [-year 2024] [-day 7] [-record] [-download]
+//	aoc bench [-year 2024] [-day 7] [-o bench.json] [-baseline bench.json]
+//	aoc new -year 2025 -day 3 [-title "Title"]
+//	aoc examples -year 2024 -day 7 saved-puzzle-page.html
+//	aoc watch -year 2024 -day 7 [-interval 1s]
+//	aoc list
+//
+// The input file "-" reads the puzzle input from stdin. When no input file is
+// given, the input is downloaded once and then read from the cache, see package
+// input for how to configure the session token.
+//
+// Some solvers take parameters, such as the number of steps to simulate, which
+// are set with -param key=value and otherwise keep their defaults. List shows
+// the parameters each solver accepts.
+//
+// Run prints the answers as text by default. With -format json or markdown it
+// prints each part's answer and its type, how long parsing and solving took,
+// and any diagnostics the solver reports, for scripts and CI to read.
+//
+// Verify solves every puzzle that has an input, either at YEAR/dayN/input.txt or
+// in the download cache, and checks the answers against the known answers in
+// answers.json. It exits with
+// a non-zero status when any answer has changed.
+//
+// Bench times parsing the input and solving each part, for every puzzle that
+// has an input, along with the memory allocated. The results can be saved as
+// JSON or CSV, and compared with earlier results to catch regressions.
+//
+// New generates the package for a new day, with a solver stub that is
+// registered with this command, a test of the example input, and a README.
+//
+// Examples extracts the example inputs and answers from a saved copy of the
+// puzzle description, into the day's testdata directory and a table test.
+//
+// Watch polls the day's directory and its input for changes. On each change it
+// rebuilds, then runs every example in testdata and the real input, showing the
+// answers and whether the examples match the answers expected by the tests.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+func main() {
+	log.SetFlags(0)
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCommand(os.Args[2:])
+	case "verify":
+		err = verifyCommand(os.Args[2:])
+	case "bench":
+		err = benchCommand(os.Args[2:])
+	case "new":
+		err = newCommand(os.Args[2:])
+	case "examples":
+		err = examplesCommand(os.Args[2:])
+	case "watch":
+		err = watchCommand(os.Args[2:])
+	case "list":
+		err = listCommand(os.Args[2:])
+	case "help", "-h", "-help", "--help":
+		usage()
+		return
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage: aoc <command> [arguments]
+
+Commands:
+  run       run the solution for a year and day
+  verify    check the solutions against their known answers
+  bench     time the solutions and compare with a baseline
+  new       generate the package for a new day
+  examples  extract the examples from a saved puzzle page into tests
+  watch     rerun a day's examples and input whenever they change
+  list      list the registered solutions
+`)
+}
+
+func runCommand(args []string) error {
+	flags := flag.NewFlagSet("run", flag.ExitOnError)
+	year := flags.Int("year", 0, "puzzle `year`, e.g. 2024")
+	day := flags.Int("day", 0, "puzzle `day`, 1-25")
+	part := flags.Int("part", 0, "puzzle `part` to run, or 0 to run both parts")
+	params := make(paramValues)
+	flags.Var(params, "param", "set a solver parameter, as `key=value`, may be repeated")
+	format := flags.String("format", formatText, "output `format`: text, json or markdown")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: aoc run -year Y -day D [-part P] [-param key=value] [-format F] [input file | -]\n")
+		flags.PrintDefaults()
+		if p, found := puzzle.Lookup(*year, *day); found && puzzle.Params(p.New()) != nil {
+			fmt.Fprintf(flags.Output(), "\nParameters for %d day %d:\n", *year, *day)
+			printParams(flags.Output(), p.New())
+		}
+	}
+	_ = flags.Parse(args)
+
+	p, err := lookupPuzzle(*year, *day)
+	if err != nil {
+		return err
+	}
+	parts := []int{1, 2}
+	switch *part {
+	case 0:
+	case 1, 2:
+		parts = []int{*part}
+	default:
+		return fmt.Errorf("cannot run part %d, expected 1 or 2", *part)
+	}
+	if !slices.Contains(formats, *format) {
+		return fmt.Errorf("unknown format %q, expected one of: %s", *format, strings.Join(formats, ", "))
+	}
+
+	solver := p.New()
+	if err := puzzle.SetParams(solver, params); err != nil {
+		return fmt.Errorf("%d day %d: %w", *year, *day, err)
+	}
+
+	input, err := openInput(flags.Arg(0), *year, *day)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	start := time.Now()
+	if err := solver.Parse(input); err != nil {
+		return fmt.Errorf("parsing %d day %d: %w", *year, *day, err)
+	}
+	parsed := time.Since(start)
+
+	var results []runResult
+	for _, n := range parts {
+		start := time.Now()
+		answer, err := puzzle.SolvePart(solver, n)
+		r := newRunResult(*year, *day, n, answer, parsed, time.Since(start))
+		switch {
+		case errors.Is(err, puzzle.ErrNotImplemented) && *part == 0:
+			r.Error = "not implemented"
+		case err != nil:
+			return fmt.Errorf("%d day %d part %d: %w", *year, *day, n, err)
+		default:
+			r.Diagnostics = puzzle.Diagnostics(solver)
+		}
+		results = append(results, r)
+	}
+	return printRunResults(os.Stdout, *format, results)
+}
+
+func listCommand(args []string) error {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	_ = flags.Parse(args)
+
+	for _, p := range puzzle.All() {
+		fmt.Printf("%d day %d\n", p.Year, p.Day)
+		printParams(os.Stdout, p.New())
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+var templates = template.Must(template.ParseFS(templateFS, "templates/*.tmpl"))
+
+// scaffold describes the day package to generate.
+type scaffold struct {
+	Year  int
+	Day   int
+	Title string
+}
+
+// scaffoldFiles maps each generated file, relative to the day directory, to
+// the template it is generated from.
+var scaffoldFiles = []struct {
+	name, template string
+}{
+	{"main.go", "main.go.tmpl"},
+	{"main_test.go", "main_test.go.tmpl"},
+	{"README.md", "README.md.tmpl"},
+	{filepath.Join("testdata", "example.txt"), ""},
+}
+
+func newCommand(args []string) error {
+	flags := flag.NewFlagSet("new", flag.ExitOnError)
+	year := flags.Int("year", 0, "puzzle `year`, e.g. 2025")
+	day := flags.Int("day", 0, "puzzle `day`, 1-25")
+	title := flags.String("title", "", "puzzle `title` for the README files")
+	root := flags.String("root", ".", "root `directory` of the repository")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: aoc new -year Y -day D [-title T] [-root dir]\n")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if *year < 2015 {
+		return fmt.Errorf("cannot create year %d, expected 2015 or later", *year)
+	}
+	if *day < 1 || *day > 25 {
+		return fmt.Errorf("cannot create day %d, expected 1-25", *day)
+	}
+
+	s := scaffold{Year: *year, Day: *day, Title: *title}
+	if s.Title == "" {
+		s.Title = fmt.Sprintf("Day %d", *day)
+	}
+
+	created, err := generateDay(*root, s)
+	for _, path := range created {
+		fmt.Println("created", path)
+	}
+	return err
+}
+
+// generateDay writes a new day package, registers it with the aoc command and
+// adds it to the year's README. It refuses to write over any existing file, or
+// into a directory that already holds Go source, and returns the paths it has
+// written.
+func generateDay(root string, s scaffold) ([]string, error) {
+	dir := filepath.Join(root, fmt.Sprint(s.Year), fmt.Sprintf("day%d", s.Day))
+	sources, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) > 0 {
+		return nil, fmt.Errorf("refusing to write into %s, it already holds %s", dir, filepath.Base(sources[0]))
+	}
+	for _, f := range scaffoldFiles {
+		path := filepath.Join(dir, f.name)
+		if _, err := os.Stat(path); !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("refusing to overwrite %s", path)
+		}
+	}
+
+	var created []string
+	for _, f := range scaffoldFiles {
+		path := filepath.Join(dir, f.name)
+		var content bytes.Buffer
+		if f.template != "" {
+			if err := templates.ExecuteTemplate(&content, f.template, s); err != nil {
+				return created, err
+			}
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return created, err
+		}
+		if err := os.WriteFile(path, content.Bytes(), 0o644); err != nil {
+			return created, err
+		}
+		created = append(created, path)
+	}
+
+	if err := addSolutionImport(root, s); err != nil {
+		return created, err
+	}
+	return created, addToYearIndex(root, s)
+}
+
+// addSolutionImport adds the new day to the blank imports in solutions.go, so
+// that it is registered with the aoc command.
+func addSolutionImport(root string, s scaffold) error {
+	module, err := modulePath(root)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(root, "cmd", "aoc", "solutions.go")
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	importSpec := fmt.Sprintf("\t_ \"%s/%d/day%d\"\n", module, s.Year, s.Day)
+	if bytes.Contains(src, []byte(importSpec)) {
+		return nil
+	}
+	end := bytes.LastIndex(src, []byte("\n)"))
+	if end < 0 {
+		return fmt.Errorf("no import block found in %s", path)
+	}
+	src = append(src[:end+1], append([]byte(importSpec), src[end+1:]...)...)
+
+	// gofmt puts the new import in order
+	src, err = format.Source(src)
+	if err != nil {
+		return fmt.Errorf("formatting %s: %w", path, err)
+	}
+	return os.WriteFile(path, src, 0o644)
+}
+
+// modulePath reads the module path from the go.mod file at the root.
+func modulePath(root string) (string, error) {
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if module, found := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); found {
+			return strings.TrimSpace(module), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no module path found in %s", f.Name())
+}
+
+// addToYearIndex links the new day from the year's README, creating the README
+// for a new year.
+func addToYearIndex(root string, s scaffold) error {
+	path := filepath.Join(root, fmt.Sprint(s.Year), "README.md")
+	index, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		var content bytes.Buffer
+		if err := templates.ExecuteTemplate(&content, "year_README.md.tmpl", s); err != nil {
+			return err
+		}
+		index, err = content.Bytes(), nil
+	}
+	if err != nil {
+		return err
+	}
+
+	entry := fmt.Sprintf("\n## Day %d - %s\n\n[Day %d commentary](day%d)\n", s.Day, s.Title, s.Day, s.Day)
+	index = append(bytes.TrimRight(index, "\n"), '\n')
+	index = append(index, entry...)
+	return os.WriteFile(path, index, 0o644)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestRepository(t *testing.T) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/aoc\n\ngo 1.25\n",
+		filepath.Join("cmd", "aoc", "solutions.go"): `package main
+
+import (
+	_ "example.com/aoc/2025/day1"
+	_ "example.com/aoc/2025/day4"
+)
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestGenerateDay(t *testing.T) {
+	root := newTestRepository(t)
+
+	created, err := generateDay(root, scaffold{Year: 2025, Day: 3, Title: "Lobby"})
+	if err != nil {
+		t.Fatalf("generateDay() error = %v", err)
+	}
+	if len(created) != len(scaffoldFiles) {
+		t.Errorf("generateDay() created %v, want %d files", created, len(scaffoldFiles))
+	}
+
+	mainGo := readFile(t, filepath.Join(root, "2025", "day3", "main.go"))
+	for _, want := range []string{"package day3", "puzzle.Register(2025, 3,"} {
+		if !strings.Contains(mainGo, want) {
+			t.Errorf("main.go does not contain %q:\n%s", want, mainGo)
+		}
+	}
+
+	mainTest := readFile(t, filepath.Join(root, "2025", "day3", "main_test.go"))
+	for _, want := range []string{"puzzle.Lookup(2025, 3)", "p.Solve(tt.part, f)", "puzzle.FormatAnswer(got) != tt.want"} {
+		if !strings.Contains(mainTest, want) {
+			t.Errorf("main_test.go does not contain %q:\n%s", want, mainTest)
+		}
+	}
+
+	solutions := readFile(t, filepath.Join(root, "cmd", "aoc", "solutions.go"))
+	day1 := strings.Index(solutions, "2025/day1")
+	day3 := strings.Index(solutions, `_ "example.com/aoc/2025/day3"`)
+	day4 := strings.Index(solutions, "2025/day4")
+	if day3 < 0 || day1 > day3 || day3 > day4 {
+		t.Errorf("solutions.go does not import day3 in order:\n%s", solutions)
+	}
+
+	index := readFile(t, filepath.Join(root, "2025", "README.md"))
+	if !strings.HasPrefix(index, "# Advent of Code 2025") || !strings.Contains(index, "## Day 3 - Lobby\n\n[Day 3 commentary](day3)") {
+		t.Errorf("README.md does not link to day 3:\n%s", index)
+	}
+}
+
+func TestGenerateDay_RefusesToOverwrite(t *testing.T) {
+	root := newTestRepository(t)
+	mainGo := filepath.Join(root, "2025", "day3", "main.go")
+	if err := os.MkdirAll(filepath.Dir(mainGo), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(mainGo, []byte("package day3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := generateDay(root, scaffold{Year: 2025, Day: 3, Title: "Day 3"}); err == nil {
+		t.Fatal("Expected generateDay to refuse to overwrite main.go")
+	}
+	if got := readFile(t, mainGo); got != "package day3\n" {
+		t.Errorf("main.go was overwritten with:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(root, "2025", "day3", "README.md")); err == nil {
+		t.Error("Expected no files to be generated")
+	}
+}
+
+func TestGenerateDay_RefusesDirectoryWithGoSource(t *testing.T) {
+	root := newTestRepository(t)
+	solverGo := filepath.Join(root, "2025", "day3", "solver.go")
+	if err := os.MkdirAll(filepath.Dir(solverGo), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(solverGo, []byte("package day3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := generateDay(root, scaffold{Year: 2025, Day: 3, Title: "Day 3"}); err == nil {
+		t.Fatal("Expected generateDay to refuse a directory holding solver.go")
+	}
+	if _, err := os.Stat(filepath.Join(root, "2025", "day3", "main.go")); err == nil {
+		t.Error("Expected no files to be generated")
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+// paramValues collects repeated -param key=value flags.
+type paramValues map[string]string
+
+func (v paramValues) String() string {
+	var pairs []string
+	for _, name := range slices.Sorted(maps.Keys(v)) {
+		pairs = append(pairs, name+"="+v[name])
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (v paramValues) Set(s string) error {
+	name, value, found := strings.Cut(s, "=")
+	if !found || name == "" {
+		return fmt.Errorf("expected key=value, got %q", s)
+	}
+	v[name] = value
+	return nil
+}
+
+// lookupPuzzle finds the solution for the year and day, and otherwise names the
+// years or days that do have solutions.
+func lookupPuzzle(year, day int) (puzzle.Puzzle, error) {
+	if p, found := puzzle.Lookup(year, day); found {
+		return p, nil
+	}
+
+	var years, days []string
+	for _, p := range puzzle.All() {
+		if y := fmt.Sprint(p.Year); !slices.Contains(years, y) {
+			years = append(years, y)
+		}
+		if p.Year == year {
+			days = append(days, fmt.Sprint(p.Day))
+		}
+	}
+	if len(days) == 0 {
+		return puzzle.Puzzle{}, fmt.Errorf("no solutions registered for %d, expected one of: %s", year, strings.Join(years, ", "))
+	}
+	return puzzle.Puzzle{}, fmt.Errorf("no solution registered for %d day %d, expected one of days: %s", year, day, strings.Join(days, ", "))
+}
+
+// printParams writes the parameters a solver accepts, with their defaults and
+// help text.
+func printParams(out io.Writer, s puzzle.Solver) {
+	params := puzzle.Params(s)
+	if params == nil {
+		return
+	}
+	params.VisitAll(func(f *flag.Flag) {
+		fmt.Fprintf(out, "  -param %s=%s\n    \t%s\n", f.Name, f.DefValue, f.Usage)
+	})
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+func TestParamValues_Set(t *testing.T) {
+	params := make(paramValues)
+	for _, s := range []string{"steps=10", "label=a=b", "steps=20"} {
+		if err := params.Set(s); err != nil {
+			t.Fatalf("Set(%q) error = %v", s, err)
+		}
+	}
+	if got := params.String(); got != "label=a=b,steps=20" {
+		t.Errorf("String() = %q, want %q", got, "label=a=b,steps=20")
+	}
+
+	for _, s := range []string{"steps", "=10"} {
+		if err := params.Set(s); err == nil {
+			t.Errorf("Set(%q) expected an error", s)
+		}
+	}
+}
+
+func TestLookupPuzzle_NamesRegisteredDays(t *testing.T) {
+	if _, err := lookupPuzzle(2021, 30); err == nil || !strings.Contains(err.Error(), "expected one of days: 1, 2,") {
+		t.Errorf("lookupPuzzle() error = %v, want it to name the registered days", err)
+	}
+	if _, err := lookupPuzzle(1900, 1); err == nil || !strings.Contains(err.Error(), "2024") {
+		t.Errorf("lookupPuzzle() error = %v, want it to name the registered years", err)
+	}
+}
+
+func TestSetParams_RejectsOutOfRangeValues(t *testing.T) {
+	tests := []struct {
+		year, day int
+		param     string
+		value     string
+	}{
+		{2021, 1, "part1-window", "0"},
+		{2021, 1, "part2-window", "-1"},
+		{2021, 6, "part1-days", "-1"},
+		{2021, 6, "part2-days", "-1"},
+		{2021, 11, "steps", "-1"},
+		{2021, 14, "steps", "-1"},
+		{2022, 6, "packet", "0"},
+		{2022, 6, "message", "-1"},
+		{2022, 7, "disk-size", "-1"},
+		{2022, 7, "update-size", "80000000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.param+"="+tt.value, func(t *testing.T) {
+			p, err := lookupPuzzle(tt.year, tt.day)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = puzzle.SetParams(p.New(), map[string]string{tt.param: tt.value})
+			if !errors.Is(err, puzzle.ErrInvalidParam) {
+				t.Errorf("%d day %d SetParams() error = %v, want %v", tt.year, tt.day, err, puzzle.ErrInvalidParam)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+// Every solution registers itself with the puzzle package when imported.
+import (
+	_ "github.com/neilfenwick/advent-of-code/2017/day1"
+	_ "github.com/neilfenwick/advent-of-code/2017/day2"
+	_ "github.com/neilfenwick/advent-of-code/2017/day3"
+	_ "github.com/neilfenwick/advent-of-code/2017/day4"
+	_ "github.com/neilfenwick/advent-of-code/2017/day5"
+	_ "github.com/neilfenwick/advent-of-code/2017/day6"
+	_ "github.com/neilfenwick/advent-of-code/2017/day7"
+	_ "github.com/neilfenwick/advent-of-code/2017/day8"
+	_ "github.com/neilfenwick/advent-of-code/2019/day1"
+	_ "github.com/neilfenwick/advent-of-code/2020/day1"
+	_ "github.com/neilfenwick/advent-of-code/2020/day2"
+	_ "github.com/neilfenwick/advent-of-code/2020/day3"
+	_ "github.com/neilfenwick/advent-of-code/2020/day4"
+	_ "github.com/neilfenwick/advent-of-code/2020/day5"
+	_ "github.com/neilfenwick/advent-of-code/2020/day6"
+	_ "github.com/neilfenwick/advent-of-code/2021/day1"
+	_ "github.com/neilfenwick/advent-of-code/2021/day10"
+	_ "github.com/neilfenwick/advent-of-code/2021/day11"
+	_ "github.com/neilfenwick/advent-of-code/2021/day12"
+	_ "github.com/neilfenwick/advent-of-code/2021/day13"
+	_ "github.com/neilfenwick/advent-of-code/2021/day14"
+	_ "github.com/neilfenwick/advent-of-code/2021/day2"
+	_ "github.com/neilfenwick/advent-of-code/2021/day3"
+	_ "github.com/neilfenwick/advent-of-code/2021/day4"
+	_ "github.com/neilfenwick/advent-of-code/2021/day5"
+	_ "github.com/neilfenwick/advent-of-code/2021/day6"
+	_ "github.com/neilfenwick/advent-of-code/2021/day7"
+	_ "github.com/neilfenwick/advent-of-code/2021/day8"
+	_ "github.com/neilfenwick/advent-of-code/2021/day9"
+	_ "github.com/neilfenwick/advent-of-code/2022/day1"
+	_ "github.com/neilfenwick/advent-of-code/2022/day2"
+	_ "github.com/neilfenwick/advent-of-code/2022/day3"
+	_ "github.com/neilfenwick/advent-of-code/2022/day4"
+	_ "github.com/neilfenwick/advent-of-code/2022/day5"
+	_ "github.com/neilfenwick/advent-of-code/2022/day6"
+	_ "github.com/neilfenwick/advent-of-code/2022/day7"
+	_ "github.com/neilfenwick/advent-of-code/2023/day1"
+	_ "github.com/neilfenwick/advent-of-code/2023/day2"
+	_ "github.com/neilfenwick/advent-of-code/2024/day1"
+	_ "github.com/neilfenwick/advent-of-code/2024/day2"
+	_ "github.com/neilfenwick/advent-of-code/2024/day3"
+	_ "github.com/neilfenwick/advent-of-code/2024/day4"
+	_ "github.com/neilfenwick/advent-of-code/2024/day5"
+	_ "github.com/neilfenwick/advent-of-code/2024/day6"
+	_ "github.com/neilfenwick/advent-of-code/2024/day7"
+	_ "github.com/neilfenwick/advent-of-code/2024/day8"
+)
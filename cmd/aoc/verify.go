@@ -0,0 +1,211 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+// The outcome of verifying one part of a puzzle against its known answer.
+const (
+	statusPass    = "pass"
+	statusFail    = "FAIL"
+	statusMissing = "missing"
+	statusSkip    = "skip"
+)
+
+type verifyResult struct {
+	year, day, part int
+	input           string
+	status          string
+	detail          string
+}
+
+// puzzleInput is how the puzzle input is named in the results, to tell it
+// apart from the examples.
+const puzzleInput = "input"
+
+func verifyCommand(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	year := flags.Int("year", 0, "only verify puzzles from this `year`")
+	day := flags.Int("day", 0, "only verify puzzles from this `day`")
+	answersPath := flags.String("answers", "answers.json", "`file` of known answers")
+	inputDir := flags.String("inputs", ".", "`directory` holding YEAR/dayN/input.txt puzzle inputs")
+	root := flags.String("root", ".", "root `directory` of the repository, holding the examples")
+	record := flags.Bool("record", false, "record answers for parts that have no known answer yet")
+	download := flags.Bool("download", false, "download inputs that are not found locally or in the cache")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: aoc verify [-year Y] [-day D] [-answers file] [-inputs dir] [-root dir] [-record] [-download]\n")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	answers, err := puzzle.ReadAnswers(*answersPath)
+	if err != nil {
+		return err
+	}
+
+	open := savedInputs(*inputDir, *download)
+
+	results := verify(selectPuzzles(*year, *day), answers, open, *root, *record)
+	failed := printVerifyResults(os.Stdout, results)
+
+	if *record {
+		if err := answers.WriteFile(*answersPath); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("verify: %d failed", failed)
+	}
+	return nil
+}
+
+// verify solves the examples of every puzzle that has known example answers,
+// and both parts of every puzzle that has an input, and checks the answers
+// against the known answers. The examples are found under the day's directory
+// in root. When record is set, the answers for parts of the puzzle input
+// without a known answer are added to the store.
+func verify(puzzles []puzzle.Puzzle, answers *puzzle.Answers, open inputOpener, root string, record bool) []verifyResult {
+	var results []verifyResult
+	for _, p := range puzzles {
+		results = append(results, verifyExamples(p, answers, root)...)
+
+		solver, err := parseInput(p, func() (io.ReadCloser, error) { return open(p.Year, p.Day) })
+		if err != nil {
+			for part := 1; part <= 2; part++ {
+				result := verifyResult{year: p.Year, day: p.Day, part: part, input: puzzleInput, status: statusFail, detail: err.Error()}
+				if errors.Is(err, fs.ErrNotExist) {
+					result.status, result.detail = statusMissing, "no input"
+				}
+				results = append(results, result)
+			}
+			continue
+		}
+
+		for part := 1; part <= 2; part++ {
+			result := verifyResult{year: p.Year, day: p.Day, part: part, input: puzzleInput}
+			answer, err := solvePart(solver, part)
+			want, known := answers.Get(p.Year, p.Day, part)
+			if err == nil && !known && record {
+				got := puzzle.FormatAnswer(answer)
+				answers.Set(p.Year, p.Day, part, got)
+				result.status, result.detail = statusPass, "recorded "+summarize(got)
+			} else {
+				result.status, result.detail = checkAnswer(answer, err, want, known)
+			}
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// verifyExamples checks the known answers for the examples of the puzzle, which
+// are checked in with the day's code, so need no puzzle input.
+func verifyExamples(p puzzle.Puzzle, answers *puzzle.Answers, root string) []verifyResult {
+	var results []verifyResult
+	var solver puzzle.Solver
+	var err error
+	examples := answers.Examples(p.Year, p.Day)
+	for i, example := range examples {
+		if i == 0 || example.Input != examples[i-1].Input {
+			path := filepath.Join(dayPath(root, p.Year, p.Day), filepath.FromSlash(example.Input))
+			solver, err = parseInput(p, func() (io.ReadCloser, error) { return os.Open(path) })
+		}
+
+		result := verifyResult{year: p.Year, day: p.Day, part: example.Part, input: example.Input}
+		if err != nil {
+			result.status, result.detail = statusFail, err.Error()
+		} else {
+			answer, err := solvePart(solver, example.Part)
+			result.status, result.detail = checkAnswer(answer, err, example.Answer, true)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// checkAnswer compares the answer to a part with the known answer.
+func checkAnswer(answer puzzle.Answer, err error, want string, known bool) (status, detail string) {
+	got := puzzle.FormatAnswer(answer)
+	switch {
+	case errors.Is(err, puzzle.ErrNotImplemented):
+		return statusSkip, "not implemented"
+	case err != nil:
+		return statusFail, err.Error()
+	case !known:
+		return statusMissing, "no known answer, got " + summarize(got)
+	case got != want:
+		return statusFail, fmt.Sprintf("got %s, want %s", summarize(got), summarize(want))
+	default:
+		return statusPass, summarize(got)
+	}
+}
+
+// parseInput creates a solver for the puzzle, and parses the input. A panic
+// while parsing is returned as an error, so that one broken solver does not
+// stop the rest of the puzzles being verified.
+func parseInput(p puzzle.Puzzle, open func() (io.ReadCloser, error)) (_ puzzle.Solver, err error) {
+	f, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	defer recoverPanic(&err)
+
+	solver := p.New()
+	if err := solver.Parse(f); err != nil {
+		return nil, fmt.Errorf("parsing input: %w", err)
+	}
+	return solver, nil
+}
+
+// solvePart solves one part of the puzzle, returning a panic as an error.
+func solvePart(s puzzle.Solver, part int) (_ puzzle.Answer, err error) {
+	defer recoverPanic(&err)
+	return puzzle.SolvePart(s, part)
+}
+
+// recoverPanic is deferred to turn a panic into the error returned by the
+// function deferring it.
+func recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("panic: %v", r)
+	}
+}
+
+// printVerifyResults writes the results as a table followed by a summary, and
+// returns the number of failures.
+func printVerifyResults(out io.Writer, results []verifyResult) int {
+	counts := make(map[string]int)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "YEAR\tDAY\tPART\tINPUT\tSTATUS\tDETAIL")
+	for _, r := range results {
+		counts[r.status]++
+		fmt.Fprintf(w, "%d\t%d\t%d\t%s\t%s\t%s\n", r.year, r.day, r.part, r.input, r.status, r.detail)
+	}
+	w.Flush()
+
+	fmt.Fprintf(out, "\n%d passed, %d failed, %d missing, %d skipped\n",
+		counts[statusPass], counts[statusFail], counts[statusMissing], counts[statusSkip])
+	return counts[statusFail]
+}
+
+// summarize shortens an answer to fit on one line of the results table.
+func summarize(answer string) string {
+	const maxLength = 40
+	answer = strings.ReplaceAll(answer, "\n", `\n`)
+	if len(answer) > maxLength {
+		answer = answer[:maxLength-3] + "..."
+	}
+	return answer
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+// lineCounter counts the lines in its input for part 1, and has no part 2 yet.
+type lineCounter struct {
+	lines int
+}
+
+func (l *lineCounter) Parse(input io.Reader) error {
+	b, err := io.ReadAll(input)
+	l.lines = strings.Count(string(b), "\n")
+	return err
+}
+
+func (l *lineCounter) Part1() (puzzle.Answer, error) {
+	return l.lines, nil
+}
+
+func (l *lineCounter) Part2() (puzzle.Answer, error) {
+	return nil, puzzle.ErrNotImplemented
+}
+
+func localInputs(dir string) inputOpener {
+	return func(year, day int) (io.ReadCloser, error) {
+		return os.Open(inputPath(dir, year, day))
+	}
+}
+
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	for _, day := range []int{1, 2, 3} {
+		path := inputPath(dir, 1915, day)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("a\nb\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var puzzles []puzzle.Puzzle
+	for day := 1; day <= 4; day++ {
+		puzzles = append(puzzles, puzzle.Puzzle{Year: 1915, Day: day, New: func() puzzle.Solver { return &lineCounter{} }})
+	}
+	answers := puzzle.NewAnswers()
+	answers.Set(1915, 1, 1, "2")
+	answers.Set(1915, 2, 1, "3")
+
+	results := verify(puzzles, answers, localInputs(dir), dir, false)
+
+	want := map[[2]int]string{
+		{1, 1}: statusPass,
+		{1, 2}: statusSkip,
+		{2, 1}: statusFail,
+		{3, 1}: statusMissing,
+		{4, 1}: statusMissing,
+		{4, 2}: statusMissing,
+	}
+	for _, r := range results {
+		if status, found := want[[2]int{r.day, r.part}]; found && r.status != status {
+			t.Errorf("day %d part %d status = %s (%s), want %s", r.day, r.part, r.status, r.detail, status)
+		}
+	}
+
+	if failed := printVerifyResults(io.Discard, results); failed != 1 {
+		t.Errorf("printVerifyResults() = %d failed, want 1", failed)
+	}
+}
+
+func TestVerify_RecordsUnknownAnswers(t *testing.T) {
+	dir := t.TempDir()
+	path := inputPath(dir, 1915, 1)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("a\nb\nc\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := puzzle.Puzzle{Year: 1915, Day: 1, New: func() puzzle.Solver { return &lineCounter{} }}
+	answers := puzzle.NewAnswers()
+	verify([]puzzle.Puzzle{p}, answers, localInputs(dir), dir, true)
+
+	if got, found := answers.Get(1915, 1, 1); !found || got != "3" {
+		t.Errorf("recorded answer = %q, %v, want 3", got, found)
+	}
+	if _, found := answers.Get(1915, 1, 2); found {
+		t.Error("Expected no answer recorded for an unimplemented part")
+	}
+}
+
+func writeInput(t *testing.T, path, input string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerify_Examples(t *testing.T) {
+	dir := t.TempDir()
+	writeInput(t, filepath.Join(dayPath(dir, 1915, 1), "testdata", "example.txt"), "a\nb\n")
+
+	p := puzzle.Puzzle{Year: 1915, Day: 1, New: func() puzzle.Solver { return &lineCounter{} }}
+	answers := puzzle.NewAnswers()
+	answers.SetExample(1915, 1, 1, "testdata/example.txt", "2")
+	answers.SetExample(1915, 1, 1, "testdata/example2.txt", "3")
+
+	want := map[string]string{
+		"testdata/example.txt":  statusPass,
+		"testdata/example2.txt": statusFail,
+		puzzleInput:             statusMissing,
+	}
+	for _, r := range verify([]puzzle.Puzzle{p}, answers, localInputs(dir), dir, false) {
+		if r.status != want[r.input] {
+			t.Errorf("%s part %d status = %s (%s), want %s", r.input, r.part, r.status, r.detail, want[r.input])
+		}
+	}
+}
+
+// panicker panics while parsing its input, or while solving part 1.
+type panicker struct {
+	lineCounter
+	inParse bool
+}
+
+func (p *panicker) Parse(input io.Reader) error {
+	if p.inParse {
+		panic("cannot parse")
+	}
+	return p.lineCounter.Parse(input)
+}
+
+func (p *panicker) Part1() (puzzle.Answer, error) {
+	var lines []int
+	return lines[p.lines], nil
+}
+
+func TestVerify_RecoversFromPanics(t *testing.T) {
+	dir := t.TempDir()
+	for _, day := range []int{1, 2, 3} {
+		writeInput(t, inputPath(dir, 1915, day), "a\nb\n")
+	}
+	puzzles := []puzzle.Puzzle{
+		{Year: 1915, Day: 1, New: func() puzzle.Solver { return &panicker{inParse: true} }},
+		{Year: 1915, Day: 2, New: func() puzzle.Solver { return &panicker{} }},
+		{Year: 1915, Day: 3, New: func() puzzle.Solver { return &lineCounter{} }},
+	}
+	answers := puzzle.NewAnswers()
+	answers.Set(1915, 3, 1, "2")
+
+	results := verify(puzzles, answers, localInputs(dir), dir, false)
+
+	want := map[[2]int]string{
+		{1, 1}: "panic: cannot parse",
+		{1, 2}: "panic: cannot parse",
+		{2, 1}: "panic: runtime error: index out of range [2] with length 0",
+	}
+	for _, r := range results {
+		detail, found := want[[2]int{r.day, r.part}]
+		switch {
+		case found && (r.status != statusFail || !strings.Contains(r.detail, detail)):
+			t.Errorf("day %d part %d = %s (%s), want %s (%s)", r.day, r.part, r.status, r.detail, statusFail, detail)
+		case r.day == 3 && r.part == 1 && r.status != statusPass:
+			t.Errorf("day 3 part 1 status = %s (%s), want %s", r.status, r.detail, statusPass)
+		}
+	}
+}
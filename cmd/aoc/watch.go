@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/neilfenwick/advent-of-code/input"
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+// fileState is what is compared to tell whether a watched file has changed.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+type expectedKey struct {
+	input string
+	part  int
+}
+
+func watchCommand(args []string) error {
+	flags := flag.NewFlagSet("watch", flag.ExitOnError)
+	year := flags.Int("year", 0, "puzzle `year`, e.g. 2024")
+	day := flags.Int("day", 0, "puzzle `day`, 1-25")
+	root := flags.String("root", ".", "root `directory` of the repository")
+	inputDir := flags.String("inputs", ".", "`directory` holding YEAR/dayN/input.txt puzzle inputs")
+	answersPath := flags.String("answers", "answers.json", "`file` of known answers")
+	interval := flags.Duration("interval", time.Second, "how often to check for changes")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: aoc watch -year Y -day D [-answers file] [-interval 1s]\n")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if _, err := lookupPuzzle(*year, *day); err != nil {
+		return err
+	}
+	dir := filepath.Join(*root, fmt.Sprint(*year), fmt.Sprintf("day%d", *day))
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("no package for %d day %d at %s", *year, *day, dir)
+	}
+
+	bin, err := os.MkdirTemp("", "aoc-watch")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(bin)
+	bin = filepath.Join(bin, "aoc")
+
+	realInputs := []string{inputPath(*inputDir, *year, *day)}
+	if client, err := input.NewClient(); err == nil {
+		realInputs = append(realInputs, client.CachePath(*year, *day))
+	}
+	paths := append([]string{dir, *answersPath}, realInputs...)
+
+	// stop on interrupt, so that the built command is removed
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var last map[string]fileState
+	for {
+		current := snapshot(paths...)
+		if !maps.Equal(last, current) {
+			last = current
+			fmt.Printf("\n%s %d day %d changed, rebuilding\n", time.Now().Format(time.TimeOnly), *year, *day)
+			if err := rebuild(*root, bin); err != nil {
+				fmt.Println(err)
+			} else {
+				runWatched(os.Stdout, bin, dir, realInputs, *answersPath, *year, *day)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(*interval):
+		}
+	}
+}
+
+// snapshot records the size and modification time of every file found under
+// the paths. Paths that do not exist are left out.
+func snapshot(paths ...string) map[string]fileState {
+	files := make(map[string]fileState)
+	for _, path := range paths {
+		_ = filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			if info, err := d.Info(); err == nil {
+				files[path] = fileState{size: info.Size(), modTime: info.ModTime()}
+			}
+			return nil
+		})
+	}
+	return files
+}
+
+// rebuild builds the aoc command from the repository, so that the latest code
+// of the day is run.
+func rebuild(root, bin string) error {
+	cmd := exec.Command("go", "build", "-o", bin, "./cmd/aoc")
+	cmd.Dir = root
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("build failed: %w\n%s", err, out)
+	}
+	return nil
+}
+
+// runWatched runs the rebuilt command on each example in the day's testdata,
+// and on the first of the real inputs that exists, checking the answers against
+// the known answers in the answers file.
+func runWatched(out io.Writer, bin, dir string, realInputs []string, answersPath string, year, day int) {
+	answers, err := puzzle.ReadAnswers(answersPath)
+	if err != nil {
+		fmt.Fprintln(out, err)
+		answers = puzzle.NewAnswers()
+	}
+	expected := expectedAnswers(answers, year, day)
+
+	inputs, _ := filepath.Glob(filepath.Join(dir, "testdata", "*.txt"))
+	realInput := ""
+	for _, path := range realInputs {
+		if _, err := os.Stat(path); err == nil {
+			realInput = path
+			inputs = append(inputs, path)
+			break
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "INPUT\tPART\tANSWER\tTIME\tRESULT")
+	for _, path := range inputs {
+		name := path
+		if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			name = filepath.ToSlash(rel)
+		}
+		key := name
+		if path == realInput {
+			key = puzzleInput
+		}
+
+		results, err := runSolver(bin, year, day, path)
+		if err != nil {
+			fmt.Fprintf(w, "%s\t-\t%v\t\t\n", name, err)
+			continue
+		}
+		for _, r := range results {
+			answer := fmt.Sprint(r.Answer)
+			if r.Error != "" {
+				answer = r.Error
+			}
+			fmt.Fprintf(w, "%s\t%d\t%s\t%v\t%s\n", name, r.Part, summarize(answer), time.Duration(r.ParseNs+r.SolveNs), checkResult(expected, key, r))
+		}
+	}
+	w.Flush()
+}
+
+// runSolver runs the built command on an input, reading back its results.
+func runSolver(bin string, year, day int, path string) ([]runResult, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(bin, "run", "-year", fmt.Sprint(year), "-day", fmt.Sprint(day), "-format", formatJSON, path)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s", strings.TrimSpace(stderr.String()))
+	}
+
+	var results []runResult
+	dec := json.NewDecoder(&stdout)
+	dec.UseNumber()
+	if err := dec.Decode(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// checkResult compares an answer with the known answer for the input, if there
+// is one.
+func checkResult(expected map[expectedKey]string, input string, r runResult) string {
+	want, found := expected[expectedKey{input, r.Part}]
+	switch {
+	case !found || r.Error != "":
+		return ""
+	case fmt.Sprint(r.Answer) == want:
+		return statusPass
+	default:
+		return fmt.Sprintf("%s, want %s", statusFail, summarize(want))
+	}
+}
+
+// expectedAnswers gathers the known answers for the puzzle, keyed by the path
+// of each example relative to the day's directory, and by puzzleInput for the
+// puzzle input.
+func expectedAnswers(answers *puzzle.Answers, year, day int) map[expectedKey]string {
+	expected := make(map[expectedKey]string)
+	for _, e := range answers.Examples(year, day) {
+		expected[expectedKey{e.Input, e.Part}] = e.Answer
+	}
+	for _, part := range []int{1, 2} {
+		if answer, found := answers.Get(year, day, part); found {
+			expected[expectedKey{puzzleInput, part}] = answer
+		}
+	}
+	return expected
+}
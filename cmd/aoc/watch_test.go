@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neilfenwick/advent-of-code/puzzle"
+)
+
+func TestSnapshot_DetectsChanges(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "testdata", "example.txt")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	before := snapshot(dir, filepath.Join(dir, "missing.txt"))
+	if len(before) != 1 {
+		t.Fatalf("snapshot() = %v, want the one example", before)
+	}
+	if after := snapshot(dir); !maps.Equal(before, after) {
+		t.Error("Expected the snapshot not to change when no file has")
+	}
+
+	if err := os.WriteFile(path, []byte("1\n2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if after := snapshot(dir); maps.Equal(before, after) {
+		t.Error("Expected the snapshot to change when the example is written")
+	}
+}
+
+func TestExpectedAnswers(t *testing.T) {
+	answers := puzzle.NewAnswers()
+	answers.SetExample(2022, 5, 1, "testdata/example.txt", "CMZ")
+	answers.SetExample(2022, 5, 1, "testdata/example2.txt", "42")
+	answers.Set(2022, 5, 2, "MCD")
+	answers.SetExample(2022, 6, 1, "testdata/example.txt", "7")
+
+	want := map[expectedKey]string{
+		{"testdata/example.txt", 1}:  "CMZ",
+		{"testdata/example2.txt", 1}: "42",
+		{puzzleInput, 2}:             "MCD",
+	}
+	if got := expectedAnswers(answers, 2022, 5); !maps.Equal(got, want) {
+		t.Errorf("expectedAnswers() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckResult(t *testing.T) {
+	expected := map[expectedKey]string{{"testdata/example.txt", 1}: "42"}
+
+	tests := []struct {
+		name   string
+		input  string
+		result runResult
+		want   string
+	}{
+		{name: "pass", input: "testdata/example.txt", result: runResult{Part: 1, Answer: json.Number("42")}, want: statusPass},
+		{name: "fail", input: "testdata/example.txt", result: runResult{Part: 1, Answer: json.Number("41")}, want: "FAIL, want 42"},
+		{name: "not implemented", input: "testdata/example.txt", result: runResult{Part: 1, Error: "not implemented"}, want: ""},
+		{name: "no expected answer", input: "input.txt", result: runResult{Part: 1, Answer: json.Number("41")}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := checkResult(expected, tt.input, tt.result); got != tt.want {
+				t.Errorf("checkResult() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
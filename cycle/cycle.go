@@ -0,0 +1,130 @@
+// Package cycle finds where a sequence of states, each worked out from the one
+// before, starts to repeat itself. Knowing that, the state after any number of
+// steps can be found without simulating every step.
+package cycle
+
+// Cycle describes a sequence that, from step Start, repeats the same Length
+// states over and over.
+type Cycle struct {
+	Start, Length int
+}
+
+// Step returns the earliest step with the same state as step n, which is n
+// itself before the cycle starts.
+func (c Cycle) Step(n int) int {
+	if n < c.Start || c.Length == 0 {
+		return n
+	}
+	return c.Start + (n-c.Start)%c.Length
+}
+
+// Nth returns the state after n steps from the initial state.
+func Nth[S any](initial S, next func(S) S, n int) S {
+	state := initial
+	for range n {
+		state = next(state)
+	}
+	return state
+}
+
+// FastForward returns the state after n steps from the initial state, which
+// can be far more steps than could be simulated, by finding the cycle with
+// Brent's algorithm and then stepping only as far as the matching state in the
+// first time round it.
+func FastForward[S comparable](initial S, next func(S) S, n int) S {
+	c, _ := Brent(initial, next)
+	return Nth(initial, next, c.Step(n))
+}
+
+// Floyd finds the cycle using Floyd's tortoise and hare algorithm, which holds
+// only two states at a time. It returns the first state that repeats, at the
+// start of the cycle. The sequence must eventually repeat, or it runs forever.
+func Floyd[S comparable](initial S, next func(S) S) (Cycle, S) {
+	// the hare runs at twice the speed of the tortoise, until they meet within the
+	// cycle, at a step that is a multiple of its length
+	tortoise, hare := next(initial), next(next(initial))
+	for tortoise != hare {
+		tortoise, hare = next(tortoise), next(next(hare))
+	}
+
+	// the start of the cycle is as far from the meeting point as from the initial
+	// state, so walking both at the same speed they meet there
+	start := 0
+	tortoise = initial
+	for tortoise != hare {
+		tortoise, hare = next(tortoise), next(hare)
+		start++
+	}
+
+	length := 1
+	for hare = next(tortoise); tortoise != hare; hare = next(hare) {
+		length++
+	}
+	return Cycle{Start: start, Length: length}, tortoise
+}
+
+// Brent finds the cycle using Brent's algorithm, which holds only two states at
+// a time and calls next fewer times than Floyd's. It returns the first state
+// that repeats, at the start of the cycle. The sequence must eventually repeat,
+// or it runs forever.
+func Brent[S comparable](initial S, next func(S) S) (Cycle, S) {
+	// find the length by leaving the tortoise at each power of two steps, until
+	// the hare catches up with it
+	power, length := 1, 1
+	tortoise, hare := initial, next(initial)
+	for tortoise != hare {
+		if power == length {
+			tortoise = hare
+			power *= 2
+			length = 0
+		}
+		hare = next(hare)
+		length++
+	}
+
+	// with the hare a cycle length ahead, the two meet at the start of the cycle
+	tortoise, hare = initial, Nth(initial, next, length)
+	start := 0
+	for tortoise != hare {
+		tortoise, hare = next(tortoise), next(hare)
+		start++
+	}
+	return Cycle{Start: start, Length: length}, tortoise
+}
+
+// Detection is a cycle found by remembering every state, along with the states
+// up to the end of the first time round the cycle.
+type Detection[S any] struct {
+	Cycle
+	// States holds the state at each step, up to Start+Length-1.
+	States []S
+}
+
+// At returns the state after n steps.
+func (d Detection[S]) At(n int) S {
+	return d.States[d.Step(n)]
+}
+
+// Detect finds the cycle by remembering every state seen, which needs more
+// memory than Floyd or Brent but visits each state only once.
+func Detect[S comparable](initial S, next func(S) S) Detection[S] {
+	return DetectFunc(initial, next, func(s S) S { return s })
+}
+
+// DetectFunc finds the cycle by remembering every state seen, by the key the
+// key function gives it. It is for states that are not comparable themselves,
+// such as slices, where key must give equal keys only for equal states. The
+// next function must return a new state rather than changing the one it is
+// given, as every state is kept.
+func DetectFunc[S any, K comparable](initial S, next func(S) S, key func(S) K) Detection[S] {
+	seen := make(map[K]int)
+	var states []S
+	for state, step := initial, 0; ; state, step = next(state), step+1 {
+		k := key(state)
+		if start, found := seen[k]; found {
+			return Detection[S]{Cycle: Cycle{Start: start, Length: step - start}, States: states}
+		}
+		seen[k] = step
+		states = append(states, state)
+	}
+}
@@ -0,0 +1,91 @@
+package cycle
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestDetectors(t *testing.T) {
+	// 0 -> 1 -> 2 -> 3 -> 4 -> 5 -> 2
+	next := func(n int) int {
+		if n == 5 {
+			return 2
+		}
+		return n + 1
+	}
+	want := Cycle{Start: 2, Length: 4}
+
+	detectors := map[string]func(int, func(int) int) (Cycle, int){
+		"floyd": Floyd[int],
+		"brent": Brent[int],
+		"map": func(initial int, next func(int) int) (Cycle, int) {
+			d := Detect(initial, next)
+			return d.Cycle, d.States[d.Start]
+		},
+	}
+	for name, detect := range detectors {
+		t.Run(name, func(t *testing.T) {
+			c, first := detect(0, next)
+			if c != want || first != 2 {
+				t.Errorf("Got %+v starting with %d, want %+v starting with 2", c, first, want)
+			}
+			if c, first := detect(3, next); c != (Cycle{Start: 0, Length: 4}) || first != 3 {
+				t.Errorf("From within the cycle, got %+v starting with %d", c, first)
+			}
+			if c, _ := detect(7, func(n int) int { return n }); c != (Cycle{Start: 0, Length: 1}) {
+				t.Errorf("For a fixed point, got %+v", c)
+			}
+		})
+	}
+}
+
+// TestDetectors_Agree compares the detectors on random functions, which all
+// eventually cycle as they map a small set of numbers onto itself.
+func TestDetectors_Agree(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := range 200 {
+		size := 1 + rng.Intn(100)
+		mapping := make([]int, size)
+		for j := range mapping {
+			mapping[j] = rng.Intn(size)
+		}
+		next := func(n int) int { return mapping[n] }
+		initial := rng.Intn(size)
+
+		detection := Detect(initial, next)
+		floyd, floydFirst := Floyd(initial, next)
+		brent, brentFirst := Brent(initial, next)
+		if floyd != detection.Cycle || brent != detection.Cycle {
+			t.Fatalf("Function %d: Floyd %+v and Brent %+v, want %+v", i, floyd, brent, detection.Cycle)
+		}
+		if first := detection.States[detection.Start]; floydFirst != first || brentFirst != first {
+			t.Fatalf("Function %d: first repeated states %d and %d, want %d", i, floydFirst, brentFirst, first)
+		}
+
+		for _, n := range []int{0, 1, detection.Start, detection.Start + detection.Length, 1000, 12345} {
+			want := Nth(initial, next, n)
+			if got := detection.At(n); got != want {
+				t.Fatalf("Function %d: At(%d) = %d, want %d", i, n, got, want)
+			}
+			if got := FastForward(initial, next, n); got != want {
+				t.Fatalf("Function %d: FastForward(%d) = %d, want %d", i, n, got, want)
+			}
+		}
+	}
+}
+
+func TestDetectFunc(t *testing.T) {
+	// the last digits of powers of two, held in a slice
+	next := func(digits []int) []int {
+		return []int{(digits[0] * 2) % 10}
+	}
+	d := DetectFunc([]int{1}, next, func(digits []int) string { return fmt.Sprint(digits) })
+
+	if d.Cycle != (Cycle{Start: 1, Length: 4}) {
+		t.Errorf("Got %+v, want the cycle 2, 4, 8, 6 after 1", d.Cycle)
+	}
+	if got := d.At(1_000_000_000); got[0] != 6 {
+		t.Errorf("At(1e9) = %v, want the last digit of 2^1e9, 6", got)
+	}
+}
@@ -0,0 +1,137 @@
+package data
+
+import (
+	"iter"
+	"math/bits"
+	"strings"
+)
+
+// Bitset is a set of small non-negative integers, held as one bit each in
+// 64 bit words. Sets of different widths can be combined, with the missing
+// bits of the narrower set treated as clear.
+type Bitset struct {
+	words []uint64
+	width int
+}
+
+// NewBitset creates an empty set that can hold the integers 0 to width-1.
+func NewBitset(width int) *Bitset {
+	return &Bitset{words: make([]uint64, (width+63)/64), width: width}
+}
+
+// Width returns the number of integers the set can hold.
+func (b *Bitset) Width() int {
+	return b.width
+}
+
+// Set adds the integer to the set. It panics if i is outside the width.
+func (b *Bitset) Set(i int) {
+	b.check(i)
+	b.words[i/64] |= 1 << (i % 64)
+}
+
+// Clear removes the integer from the set. It panics if i is outside the width.
+func (b *Bitset) Clear(i int) {
+	b.check(i)
+	b.words[i/64] &^= 1 << (i % 64)
+}
+
+// Test reports whether the integer is in the set.
+func (b *Bitset) Test(i int) bool {
+	if i < 0 || i >= b.width {
+		return false
+	}
+	return b.words[i/64]&(1<<(i%64)) != 0
+}
+
+func (b *Bitset) check(i int) {
+	if i < 0 || i >= b.width {
+		panic("bitset index out of range")
+	}
+}
+
+// Count returns the number of integers in the set.
+func (b *Bitset) Count() int {
+	count := 0
+	for _, w := range b.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// And returns a set of the integers in both sets.
+func (b *Bitset) And(other *Bitset) *Bitset {
+	return b.combine(other, func(x, y uint64) uint64 { return x & y })
+}
+
+// Or returns a set of the integers in either set.
+func (b *Bitset) Or(other *Bitset) *Bitset {
+	return b.combine(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// Xor returns a set of the integers in one set but not both.
+func (b *Bitset) Xor(other *Bitset) *Bitset {
+	return b.combine(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// AndNot returns a set of the integers in this set that are not in the other.
+func (b *Bitset) AndNot(other *Bitset) *Bitset {
+	return b.combine(other, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// combine applies the operation to each pair of words, giving a set as wide as
+// the wider of the two.
+func (b *Bitset) combine(other *Bitset, op func(x, y uint64) uint64) *Bitset {
+	result := NewBitset(max(b.width, other.width))
+	for i := range result.words {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+		if i < len(other.words) {
+			y = other.words[i]
+		}
+		result.words[i] = op(x, y)
+	}
+	return result
+}
+
+// Equal reports whether the sets hold the same integers, whatever their widths.
+func (b *Bitset) Equal(other *Bitset) bool {
+	return b.Xor(other).Count() == 0
+}
+
+// Copy returns a set with the same integers, which can be changed independently.
+func (b *Bitset) Copy() *Bitset {
+	c := NewBitset(b.width)
+	copy(c.words, b.words)
+	return c
+}
+
+// All iterates over the integers in the set, smallest first.
+func (b *Bitset) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range b.words {
+			for w != 0 {
+				bit := bits.TrailingZeros64(w)
+				if !yield(i*64 + bit) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// String draws the set as a 1 or 0 for each integer, starting with 0.
+func (b *Bitset) String() string {
+	var s strings.Builder
+	for i := range b.width {
+		if b.Test(i) {
+			s.WriteByte('1')
+		} else {
+			s.WriteByte('0')
+		}
+	}
+	return s.String()
+}
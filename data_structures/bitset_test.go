@@ -0,0 +1,124 @@
+package data
+
+import (
+	"slices"
+	"testing"
+)
+
+func bitsetOf(width int, values ...int) *Bitset {
+	b := NewBitset(width)
+	for _, v := range values {
+		b.Set(v)
+	}
+	return b
+}
+
+func TestBitset(t *testing.T) {
+	b := bitsetOf(130, 0, 5, 63, 64, 129)
+	if b.Count() != 5 {
+		t.Errorf("Count() = %d, want 5", b.Count())
+	}
+	if !b.Test(64) || b.Test(65) || b.Test(-1) || b.Test(130) {
+		t.Error("Expected Test() to report only the integers that were set")
+	}
+	if got := slices.Collect(b.All()); !slices.Equal(got, []int{0, 5, 63, 64, 129}) {
+		t.Errorf("All() = %v", got)
+	}
+
+	b.Clear(63)
+	if b.Test(63) || b.Count() != 4 {
+		t.Errorf("Expected 63 to be cleared, got %v", slices.Collect(b.All()))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Set() outside the width to panic")
+		}
+	}()
+	b.Set(130)
+}
+
+func TestBitset_Operations(t *testing.T) {
+	a := bitsetOf(100, 1, 2, 70, 99)
+	b := bitsetOf(70, 2, 3, 69)
+
+	tests := []struct {
+		name string
+		got  *Bitset
+		want []int
+	}{
+		{"and", a.And(b), []int{2}},
+		{"or", a.Or(b), []int{1, 2, 3, 69, 70, 99}},
+		{"xor", a.Xor(b), []int{1, 3, 69, 70, 99}},
+		{"and not", a.AndNot(b), []int{1, 70, 99}},
+		{"reverse and not", b.AndNot(a), []int{3, 69}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slices.Collect(tt.got.All()); !slices.Equal(got, tt.want) {
+				t.Errorf("Got %v, want %v", got, tt.want)
+			}
+			if tt.got.Width() != 100 {
+				t.Errorf("Width() = %d, want the wider of the two", tt.got.Width())
+			}
+		})
+	}
+
+	if !a.And(b).Equal(bitsetOf(3, 2)) {
+		t.Error("Expected sets of different widths with the same integers to be equal")
+	}
+	c := a.Copy()
+	c.Set(0)
+	if a.Test(0) {
+		t.Error("Expected changing a copy to leave the original unchanged")
+	}
+}
+
+func TestRuneSet(t *testing.T) {
+	s := NewRuneSet("vJrwpWtwJgWr")
+	if got := s.String(); got != "gprtvwJW" {
+		t.Errorf("String() = %q, want the letters a-z then A-Z", got)
+	}
+	if !s.Contains('J') || s.Contains('j') || s.Contains('1') {
+		t.Error("Expected Contains() to tell upper and lower case apart")
+	}
+	if s.Add('!') {
+		t.Error("Expected Add() to refuse a character that is not a letter")
+	}
+	s.Remove('W')
+	if s.Contains('W') || s.Count() != 7 {
+		t.Errorf("Expected W to be removed, got %v", s)
+	}
+
+	a, b := NewRuneSet("abcXY"), NewRuneSet("bcdYZ")
+	tests := []struct {
+		name string
+		got  RuneSet
+		want string
+	}{
+		{"and", a.And(b), "bcY"},
+		{"or", a.Or(b), "abcdXYZ"},
+		{"xor", a.Xor(b), "adXZ"},
+		{"and not", a.AndNot(b), "aX"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != NewRuneSet(tt.want) {
+				t.Errorf("Got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkRuneSet_Intersect(b *testing.B) {
+	lines := []string{"vJrwpWtwJgWrhcsFMMfFFhFp", "jqHRNqRjqzjGDLGLrsFMfFZSrLrFZsSL", "PmmdzqPrVvPwwTWBwg"}
+	for b.Loop() {
+		common := NewRuneSet(lines[0])
+		for _, line := range lines[1:] {
+			common = common.And(NewRuneSet(line))
+		}
+		if common.Count() != 1 {
+			b.Fatal(common)
+		}
+	}
+}
@@ -1,35 +1,110 @@
 package data
 
-type CircularBuffer struct {
-	buffer         []interface{}
+import "iter"
+
+// CircularBuffer holds the most recent items written to it, up to its size,
+// overwriting the oldest item once it is full.
+type CircularBuffer[T any] struct {
+	buffer         []T
 	WriteCursorPos int
+	length         int
 }
 
-func NewCircularBuffer(size int) *CircularBuffer {
-	return &CircularBuffer{buffer: make([]interface{}, size)}
+func NewCircularBuffer[T any](size int) *CircularBuffer[T] {
+	return &CircularBuffer[T]{buffer: make([]T, size)}
 }
 
-func (b *CircularBuffer) Write(val interface{}) {
+func (b *CircularBuffer[T]) Write(val T) {
 	b.buffer[b.WriteCursorPos] = val
 	b.WriteCursorPos = (b.WriteCursorPos + 1) % len(b.buffer)
+	if b.length < len(b.buffer) {
+		b.length++
+	}
 }
 
-func (b *CircularBuffer) Read(offset int, count int) []interface{} {
+// Read returns a copy of count items, starting offset items from the write
+// cursor. A negative offset reads back from the most recently written items.
+func (b *CircularBuffer[T]) Read(offset int, count int) []T {
 	startPos := (b.WriteCursorPos + offset) % len(b.buffer)
 	if startPos < 0 {
 		startPos = len(b.buffer) + startPos
 	}
 
+	result := make([]T, 0, count)
 	if startPos+count <= len(b.buffer) {
-		return b.buffer[startPos : startPos+count]
+		return append(result, b.buffer[startPos:startPos+count]...)
 	}
 
-	tail := b.buffer[startPos:]
-	wrap := b.buffer[0 : count-len(tail)]
-	tail = append(tail, wrap...)
-	return tail
+	result = append(result, b.buffer[startPos:]...)
+	return append(result, b.buffer[0:count-len(result)]...)
 }
 
-func (b *CircularBuffer) Size() int {
+// Size returns the number of items the buffer can hold.
+func (b *CircularBuffer[T]) Size() int {
 	return len(b.buffer)
 }
+
+// Len returns the number of items written to the buffer, up to its size.
+func (b *CircularBuffer[T]) Len() int {
+	return b.length
+}
+
+// Full reports whether the buffer holds as many items as its size, so that the
+// next write overwrites the oldest.
+func (b *CircularBuffer[T]) Full() bool {
+	return b.length == len(b.buffer)
+}
+
+// Oldest returns the item that the next write overwrites once the buffer is
+// full.
+func (b *CircularBuffer[T]) Oldest() (value T, found bool) {
+	if b.length == 0 {
+		return value, false
+	}
+	return b.buffer[(b.WriteCursorPos-b.length+len(b.buffer))%len(b.buffer)], true
+}
+
+// All iterates over the items in the buffer from the oldest to the newest.
+func (b *CircularBuffer[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		start := b.WriteCursorPos - b.length + len(b.buffer)
+		for i := 0; i < b.length; i++ {
+			if !yield(b.buffer[(start+i)%len(b.buffer)]) {
+				return
+			}
+		}
+	}
+}
+
+// CountingBuffer is a CircularBuffer that keeps count of how often each item
+// appears in it as items are written and overwritten, so that questions about
+// the current window, such as whether its items are all different, take
+// constant time.
+type CountingBuffer[T comparable] struct {
+	*CircularBuffer[T]
+	counts map[T]int
+}
+
+func NewCountingBuffer[T comparable](size int) *CountingBuffer[T] {
+	return &CountingBuffer[T]{CircularBuffer: NewCircularBuffer[T](size), counts: make(map[T]int, size)}
+}
+
+func (b *CountingBuffer[T]) Write(val T) {
+	if oldest, found := b.Oldest(); found && b.Full() {
+		if b.counts[oldest]--; b.counts[oldest] == 0 {
+			delete(b.counts, oldest)
+		}
+	}
+	b.counts[val]++
+	b.CircularBuffer.Write(val)
+}
+
+// Count returns how many times the item appears in the buffer.
+func (b *CountingBuffer[T]) Count(val T) int {
+	return b.counts[val]
+}
+
+// Distinct returns the number of different items in the buffer.
+func (b *CountingBuffer[T]) Distinct() int {
+	return len(b.counts)
+}
@@ -2,12 +2,13 @@ package data
 
 import (
 	"reflect"
+	"slices"
 	"testing"
 )
 
 func TestIntBuffer_Write(t *testing.T) {
 	type fields struct {
-		buffer         []interface{}
+		buffer         []int
 		WriteCursorPos int
 	}
 	type args struct {
@@ -20,18 +21,18 @@ func TestIntBuffer_Write(t *testing.T) {
 	}{
 		{
 			"Overwrite value increments write cursor by one",
-			fields{[]interface{}{1, 2, 3}, 1},
+			fields{[]int{1, 2, 3}, 1},
 			args{123},
 		},
 		{
 			"Write wraps back to beginning when write to end",
-			fields{[]interface{}{1, 2, 3}, 2},
+			fields{[]int{1, 2, 3}, 2},
 			args{123},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			b := &CircularBuffer{
+			b := &CircularBuffer[int]{
 				buffer:         tt.fields.buffer,
 				WriteCursorPos: tt.fields.WriteCursorPos,
 			}
@@ -46,7 +47,7 @@ func TestIntBuffer_Write(t *testing.T) {
 
 func TestIntBuffer_Read(t *testing.T) {
 	type fields struct {
-		buffer         []interface{}
+		buffer         []int
 		WriteCursorPos int
 	}
 	type args struct {
@@ -57,36 +58,36 @@ func TestIntBuffer_Read(t *testing.T) {
 		name   string
 		fields fields
 		args   args
-		want   []interface{}
+		want   []int
 	}{
 		{
 			"Read whole buffer with cursor at start",
-			fields{[]interface{}{1, 2, 3, 4, 5}, 0},
+			fields{[]int{1, 2, 3, 4, 5}, 0},
 			args{0, 5},
-			[]interface{}{1, 2, 3, 4, 5},
+			[]int{1, 2, 3, 4, 5},
 		},
 		{
 			"Read first two values with cursor at start",
-			fields{[]interface{}{1, 2, 3, 4, 5}, 0},
+			fields{[]int{1, 2, 3, 4, 5}, 0},
 			args{0, 2},
-			[]interface{}{1, 2},
+			[]int{1, 2},
 		},
 		{
 			"Read last and first value with cursor at end",
-			fields{[]interface{}{1, 2, 3, 4, 5}, 4},
+			fields{[]int{1, 2, 3, 4, 5}, 4},
 			args{0, 2},
-			[]interface{}{5, 1},
+			[]int{5, 1},
 		},
 		{
 			"Read last value with cursor at beginning and negative offset",
-			fields{[]interface{}{1, 2, 3, 4, 5}, 0},
+			fields{[]int{1, 2, 3, 4, 5}, 0},
 			args{-1, 1},
-			[]interface{}{5},
+			[]int{5},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			b := &CircularBuffer{
+			b := &CircularBuffer[int]{
 				buffer:         tt.fields.buffer,
 				WriteCursorPos: tt.fields.WriteCursorPos,
 			}
@@ -96,3 +97,69 @@ func TestIntBuffer_Read(t *testing.T) {
 		})
 	}
 }
+
+func TestCircularBuffer_ReadReturnsACopy(t *testing.T) {
+	b := NewCircularBuffer[int](3)
+	for _, v := range []int{1, 2, 3} {
+		b.Write(v)
+	}
+
+	got := b.Read(0, 3)
+	got[0] = 100
+	if again := b.Read(0, 3); !reflect.DeepEqual(again, []int{1, 2, 3}) {
+		t.Errorf("Expected changing the result of Read not to change the buffer, got %v", again)
+	}
+}
+
+func TestCircularBuffer_LenFullAll(t *testing.T) {
+	b := NewCircularBuffer[rune](3)
+	tests := []struct {
+		write    rune
+		wantLen  int
+		wantFull bool
+		wantAll  []rune
+	}{
+		{'a', 1, false, []rune("a")},
+		{'b', 2, false, []rune("ab")},
+		{'c', 3, true, []rune("abc")},
+		{'d', 3, true, []rune("bcd")},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.write), func(t *testing.T) {
+			b.Write(tt.write)
+			if b.Len() != tt.wantLen || b.Full() != tt.wantFull {
+				t.Errorf("Len(), Full() = %d, %v, want %d, %v", b.Len(), b.Full(), tt.wantLen, tt.wantFull)
+			}
+			if got := slices.Collect(b.All()); !reflect.DeepEqual(got, tt.wantAll) {
+				t.Errorf("All() = %q, want %q", got, tt.wantAll)
+			}
+			if oldest, _ := b.Oldest(); oldest != tt.wantAll[0] {
+				t.Errorf("Oldest() = %q, want %q", oldest, tt.wantAll[0])
+			}
+		})
+	}
+}
+
+func TestCountingBuffer(t *testing.T) {
+	b := NewCountingBuffer[rune](4)
+	tests := []struct {
+		write        rune
+		wantDistinct int
+		wantCountA   int
+	}{
+		{'a', 1, 1},
+		{'b', 2, 1},
+		{'a', 2, 2},
+		{'c', 3, 2},
+		{'d', 4, 1}, // the first a is overwritten
+		{'e', 4, 1},
+		{'f', 4, 0},
+	}
+	for i, tt := range tests {
+		b.Write(tt.write)
+		if b.Distinct() != tt.wantDistinct || b.Count('a') != tt.wantCountA {
+			t.Errorf("After write %d, Distinct(), Count('a') = %d, %d, want %d, %d",
+				i+1, b.Distinct(), b.Count('a'), tt.wantDistinct, tt.wantCountA)
+		}
+	}
+}
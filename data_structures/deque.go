@@ -0,0 +1,133 @@
+package data
+
+import "iter"
+
+const minDequeCapacity = 16
+
+// Deque is a double-ended queue of items of type T, backed by a ring buffer
+// that grows as items are pushed and shrinks again as they are popped, so it
+// can be used as a queue for long searches without holding on to memory.
+type Deque[T any] struct {
+	buffer []T
+	head   int
+	size   int
+}
+
+func NewDeque[T any]() *Deque[T] {
+	return &Deque[T]{buffer: make([]T, minDequeCapacity)}
+}
+
+func (d *Deque[T]) PushBack(item T) {
+	d.grow()
+	d.buffer[d.index(d.size)] = item
+	d.size++
+}
+
+func (d *Deque[T]) PushFront(item T) {
+	d.grow()
+	d.head = d.index(len(d.buffer) - 1)
+	d.buffer[d.head] = item
+	d.size++
+}
+
+func (d *Deque[T]) PopFront() (value T, found bool) {
+	if d.size == 0 {
+		return value, false
+	}
+	var zero T
+	value, d.buffer[d.head] = d.buffer[d.head], zero
+	d.head = d.index(1)
+	d.size--
+	d.shrink()
+	return value, true
+}
+
+func (d *Deque[T]) PopBack() (value T, found bool) {
+	if d.size == 0 {
+		return value, false
+	}
+	var zero T
+	i := d.index(d.size - 1)
+	value, d.buffer[i] = d.buffer[i], zero
+	d.size--
+	d.shrink()
+	return value, true
+}
+
+func (d *Deque[T]) Front() (value T, found bool) {
+	return d.At(0)
+}
+
+func (d *Deque[T]) Back() (value T, found bool) {
+	return d.At(d.size - 1)
+}
+
+// At returns the item at position i, counting from the front.
+func (d *Deque[T]) At(i int) (value T, found bool) {
+	if i < 0 || i >= d.size {
+		return value, false
+	}
+	return d.buffer[d.index(i)], true
+}
+
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// All iterates over the items from the front to the back.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.size; i++ {
+			if !yield(d.buffer[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward iterates over the items from the back to the front.
+func (d *Deque[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := d.size - 1; i >= 0; i-- {
+			if !yield(d.buffer[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// index returns the position in the buffer of the item i places from the head.
+// The buffer's length is always a power of two, so wrapping around the ring is
+// a mask rather than a division.
+func (d *Deque[T]) index(i int) int {
+	return (d.head + i) & (len(d.buffer) - 1)
+}
+
+// grow doubles the buffer when it is full.
+func (d *Deque[T]) grow() {
+	if d.buffer == nil {
+		d.buffer = make([]T, minDequeCapacity)
+	}
+	if d.size == len(d.buffer) {
+		d.resize(2 * len(d.buffer))
+	}
+}
+
+// shrink halves the buffer when it is no more than a quarter full.
+func (d *Deque[T]) shrink() {
+	if len(d.buffer) > minDequeCapacity && d.size <= len(d.buffer)/4 {
+		d.resize(len(d.buffer) / 2)
+	}
+}
+
+func (d *Deque[T]) resize(capacity int) {
+	buffer := make([]T, capacity)
+	if d.head+d.size <= len(d.buffer) {
+		copy(buffer, d.buffer[d.head:d.head+d.size])
+	} else {
+		n := copy(buffer, d.buffer[d.head:])
+		copy(buffer[n:], d.buffer[:d.size-n])
+	}
+	d.buffer = buffer
+	d.head = 0
+}
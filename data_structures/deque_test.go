@@ -0,0 +1,189 @@
+package data
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestDeque_PushPop(t *testing.T) {
+	d := NewDeque[int]()
+	d.PushBack(2)
+	d.PushBack(3)
+	d.PushFront(1)
+	d.PushFront(0)
+
+	if got := slices.Collect(d.All()); !reflect.DeepEqual(got, []int{0, 1, 2, 3}) {
+		t.Errorf("Deque.All() = %v, want [0 1 2 3]", got)
+	}
+	if got := slices.Collect(d.Backward()); !reflect.DeepEqual(got, []int{3, 2, 1, 0}) {
+		t.Errorf("Deque.Backward() = %v, want [3 2 1 0]", got)
+	}
+
+	tests := []struct {
+		name      string
+		pop       func() (int, bool)
+		wantItem  int
+		wantFound bool
+	}{
+		{"Pop front", d.PopFront, 0, true},
+		{"Pop back", d.PopBack, 3, true},
+		{"Pop back again", d.PopBack, 2, true},
+		{"Pop front again", d.PopFront, 1, true},
+		{"Already empty", d.PopFront, 0, false},
+		{"Already empty at the back", d.PopBack, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, found := tt.pop(); got != tt.wantItem || found != tt.wantFound {
+				t.Errorf("pop = (%v, %v), want (%v, %v)", got, found, tt.wantItem, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestDeque_At(t *testing.T) {
+	var d Deque[string]
+	for _, item := range []string{"b", "c"} {
+		d.PushBack(item)
+	}
+	d.PushFront("a")
+
+	tests := []struct {
+		i         int
+		wantItem  string
+		wantFound bool
+	}{
+		{0, "a", true},
+		{2, "c", true},
+		{3, "", false},
+		{-1, "", false},
+	}
+	for _, tt := range tests {
+		if got, found := d.At(tt.i); got != tt.wantItem || found != tt.wantFound {
+			t.Errorf("Deque.At(%d) = (%q, %v), want (%q, %v)", tt.i, got, found, tt.wantItem, tt.wantFound)
+		}
+	}
+	if front, _ := d.Front(); front != "a" {
+		t.Errorf("Deque.Front() = %q, want a", front)
+	}
+	if back, _ := d.Back(); back != "c" {
+		t.Errorf("Deque.Back() = %q, want c", back)
+	}
+}
+
+func TestDeque_GrowsAndShrinksAroundTheRing(t *testing.T) {
+	d := NewDeque[int]()
+	// move the head part way around the ring before growing
+	for i := 0; i < 10; i++ {
+		d.PushBack(-1)
+		d.PopFront()
+	}
+
+	const n = 1000
+	for i := 0; i < n; i++ {
+		if i%2 == 0 {
+			d.PushBack(i)
+		} else {
+			d.PushFront(i)
+		}
+	}
+	if d.Len() != n {
+		t.Fatalf("Deque.Len() = %d, want %d", d.Len(), n)
+	}
+	grown := len(d.buffer)
+
+	for i := n - 1; i >= 0; i-- {
+		pop := d.PopBack
+		if i%2 == 1 {
+			pop = d.PopFront
+		}
+		if got, _ := pop(); got != i {
+			t.Fatalf("Popped %d, want %d", got, i)
+		}
+	}
+	if d.Len() != 0 || len(d.buffer) >= grown {
+		t.Errorf("Expected the empty deque to shrink from %d, buffer is %d", grown, len(d.buffer))
+	}
+}
+
+const benchmarkItems = 1_000_000
+
+// BenchmarkQueue_PushThenPop fills the queue with a million items, then empties it.
+func BenchmarkQueue_PushThenPop(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		q := NewQueue[int]()
+		for n := 0; n < benchmarkItems; n++ {
+			q.Push(n)
+		}
+		for q.Size() > 0 {
+			q.Pop()
+		}
+	}
+}
+
+func BenchmarkDeque_PushThenPop(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		d := NewDeque[int]()
+		for n := 0; n < benchmarkItems; n++ {
+			d.PushBack(n)
+		}
+		for d.Len() > 0 {
+			d.PopFront()
+		}
+	}
+}
+
+// BenchmarkQueue_Breadth pushes two items for each one popped, like a search
+// expanding its frontier, until a million items have been pushed.
+func BenchmarkQueue_Breadth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		q := NewQueue[int]()
+		q.Push(0)
+		for pushed := 1; pushed < benchmarkItems; pushed += 2 {
+			n, _ := q.Pop()
+			q.Push(n + 1)
+			q.Push(n + 2)
+		}
+	}
+}
+
+func BenchmarkDeque_Breadth(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		d := NewDeque[int]()
+		d.PushBack(0)
+		for pushed := 1; pushed < benchmarkItems; pushed += 2 {
+			n, _ := d.PopFront()
+			d.PushBack(n + 1)
+			d.PushBack(n + 2)
+		}
+	}
+}
+
+// BenchmarkQueue_Steady keeps a hundred items in the queue while a million
+// pass through it.
+func BenchmarkQueue_Steady(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		q := NewQueue[int]()
+		for n := 0; n < 100; n++ {
+			q.Push(n)
+		}
+		for n := 0; n < benchmarkItems; n++ {
+			q.Pop()
+			q.Push(n)
+		}
+	}
+}
+
+func BenchmarkDeque_Steady(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		d := NewDeque[int]()
+		for n := 0; n < 100; n++ {
+			d.PushBack(n)
+		}
+		for n := 0; n < benchmarkItems; n++ {
+			d.PopFront()
+			d.PushBack(n)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+package data
+
+import (
+	"errors"
+	"iter"
+	"slices"
+)
+
+// ErrCycle is returned when sorting a graph that has a cycle in it.
+var ErrCycle = errors.New("graph has a cycle")
+
+// Edge is a directed link to a vertex, with the cost of following it.
+type Edge[V comparable] struct {
+	To     V
+	Weight int
+}
+
+// Path is a route through a graph, from its first vertex to its last, with the
+// total weight of the edges along it.
+type Path[V comparable] struct {
+	Vertices []V
+	Cost     int
+}
+
+// Digraph is a directed graph with weighted edges, between vertices of any
+// comparable type. Vertices and edges are kept in the order they were added,
+// so that searches visit them in a predictable order.
+type Digraph[V comparable] struct {
+	vertices []V
+	edges    map[V][]Edge[V]
+}
+
+// NewDigraph creates a new directed graph with no vertices.
+func NewDigraph[V comparable]() *Digraph[V] {
+	return &Digraph[V]{edges: make(map[V][]Edge[V])}
+}
+
+// AddVertex adds the vertex to the graph, if it is not already in it.
+func (g *Digraph[V]) AddVertex(v V) {
+	if _, found := g.edges[v]; !found {
+		g.vertices = append(g.vertices, v)
+		g.edges[v] = nil
+	}
+}
+
+// AddEdge adds an edge from one vertex to another, adding the vertices as
+// needed, or changes the weight of the edge if it is already in the graph.
+func (g *Digraph[V]) AddEdge(from, to V, weight int) {
+	g.AddVertex(from)
+	g.AddVertex(to)
+	for i, e := range g.edges[from] {
+		if e.To == to {
+			g.edges[from][i].Weight = weight
+			return
+		}
+	}
+	g.edges[from] = append(g.edges[from], Edge[V]{To: to, Weight: weight})
+}
+
+// AddUndirectedEdge adds edges both ways between the vertices, like
+// Graph.LinkNodes.
+func (g *Digraph[V]) AddUndirectedEdge(a, b V, weight int) {
+	g.AddEdge(a, b, weight)
+	g.AddEdge(b, a, weight)
+}
+
+// HasVertex reports whether the vertex is in the graph.
+func (g *Digraph[V]) HasVertex(v V) bool {
+	_, found := g.edges[v]
+	return found
+}
+
+// Edge returns the weight of the edge from one vertex to another.
+func (g *Digraph[V]) Edge(from, to V) (weight int, found bool) {
+	for _, e := range g.edges[from] {
+		if e.To == to {
+			return e.Weight, true
+		}
+	}
+	return 0, false
+}
+
+// Edges returns the edges leading out of the vertex.
+func (g *Digraph[V]) Edges(v V) []Edge[V] {
+	return g.edges[v]
+}
+
+// Vertices returns every vertex in the graph, in the order they were added.
+func (g *Digraph[V]) Vertices() []V {
+	return g.vertices
+}
+
+// Subgraph returns the graph made up of the vertices that keep reports true
+// for, and the edges between them.
+func (g *Digraph[V]) Subgraph(keep func(V) bool) *Digraph[V] {
+	sub := NewDigraph[V]()
+	for _, v := range g.vertices {
+		if keep(v) {
+			sub.AddVertex(v)
+		}
+	}
+	for _, v := range sub.vertices {
+		for _, e := range g.edges[v] {
+			if sub.HasVertex(e.To) {
+				sub.AddEdge(v, e.To, e.Weight)
+			}
+		}
+	}
+	return sub
+}
+
+// BreadthFirst iterates over the vertices reachable from start, nearest first,
+// along with the number of edges to reach each one.
+func (g *Digraph[V]) BreadthFirst(start V) iter.Seq2[V, int] {
+	return func(yield func(V, int) bool) {
+		g.breadthFirst(start, func(v V, depth int, _ map[V]V) bool {
+			return yield(v, depth)
+		})
+	}
+}
+
+// BFS finds the path from start to goal with the fewest edges.
+func (g *Digraph[V]) BFS(start, goal V) (Path[V], bool) {
+	var path Path[V]
+	found := false
+	g.breadthFirst(start, func(v V, _ int, previous map[V]V) bool {
+		if v == goal {
+			path, found = g.tracePath(previous, start, goal), true
+			return false
+		}
+		return true
+	})
+	return path, found
+}
+
+func (g *Digraph[V]) breadthFirst(start V, visit func(v V, depth int, previous map[V]V) bool) {
+	if !g.HasVertex(start) {
+		return
+	}
+	depth := map[V]int{start: 0}
+	previous := make(map[V]V)
+	queue := NewDeque[V]()
+	queue.PushBack(start)
+	for {
+		v, found := queue.PopFront()
+		if !found {
+			return
+		}
+		if !visit(v, depth[v], previous) {
+			return
+		}
+		for _, e := range g.edges[v] {
+			if _, seen := depth[e.To]; !seen {
+				depth[e.To] = depth[v] + 1
+				previous[e.To] = v
+				queue.PushBack(e.To)
+			}
+		}
+	}
+}
+
+// DepthFirst iterates over the vertices reachable from start, following each
+// edge as far as it goes before backtracking.
+func (g *Digraph[V]) DepthFirst(start V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		g.depthFirst(start, func(v V, _ []V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// DFS finds a path from start to goal by depth first search. It is not
+// necessarily the shortest path.
+func (g *Digraph[V]) DFS(start, goal V) (Path[V], bool) {
+	var path Path[V]
+	found := false
+	g.depthFirst(start, func(v V, route []V) bool {
+		if v == goal {
+			path, found = g.pathCost(slices.Clone(route)), true
+			return false
+		}
+		return true
+	})
+	return path, found
+}
+
+func (g *Digraph[V]) depthFirst(start V, visit func(v V, route []V) bool) {
+	if !g.HasVertex(start) {
+		return
+	}
+	visited := make(map[V]bool)
+	var walk func(v V, route []V) bool
+	walk = func(v V, route []V) bool {
+		visited[v] = true
+		route = append(route, v)
+		if !visit(v, route) {
+			return false
+		}
+		for _, e := range g.edges[v] {
+			if !visited[e.To] && !walk(e.To, route) {
+				return false
+			}
+		}
+		return true
+	}
+	walk(start, nil)
+}
+
+// Dijkstra finds the path from start to goal with the lowest total weight. The
+// weights must not be negative.
+func (g *Digraph[V]) Dijkstra(start, goal V) (Path[V], bool) {
+	return g.AStar(start, goal, func(V) int { return 0 })
+}
+
+// AStar finds the path from start to goal with the lowest total weight, using
+// the heuristic's estimate of the remaining cost from each vertex to the goal
+// to search the most promising vertices first. The heuristic must never
+// overestimate the cost, for the path found to be the cheapest. A vertex that
+// is reached more cheaply after it has been searched from is searched again, so
+// the heuristic need not be consistent, although it is fastest when it is.
+func (g *Digraph[V]) AStar(start, goal V, heuristic func(V) int) (Path[V], bool) {
+	type candidate struct {
+		vertex   V
+		estimate int
+	}
+	if !g.HasVertex(start) {
+		return Path[V]{}, false
+	}
+
+	cost := map[V]int{start: 0}
+	previous := make(map[V]V)
+	open := NewHeap(func(a, b candidate) bool { return a.estimate < b.estimate })
+	handles := map[V]*HeapItem[candidate]{start: open.Push(candidate{start, heuristic(start)})}
+
+	for {
+		c, found := open.Pop()
+		if !found {
+			return Path[V]{}, false
+		}
+		v := c.vertex
+		if v == goal {
+			return g.tracePath(previous, start, goal), true
+		}
+		delete(handles, v)
+
+		for _, e := range g.edges[v] {
+			newCost := cost[v] + e.Weight
+			if known, seen := cost[e.To]; seen && known <= newCost {
+				continue
+			}
+			cost[e.To] = newCost
+			previous[e.To] = v
+			next := candidate{e.To, newCost + heuristic(e.To)}
+			if handle, queued := handles[e.To]; queued {
+				open.Update(handle, next)
+			} else {
+				handles[e.To] = open.Push(next)
+			}
+		}
+	}
+}
+
+// tracePath follows the previous vertices back from the goal to the start.
+func (g *Digraph[V]) tracePath(previous map[V]V, start, goal V) Path[V] {
+	vertices := []V{goal}
+	for v := goal; v != start; {
+		v = previous[v]
+		vertices = append(vertices, v)
+	}
+	slices.Reverse(vertices)
+	return g.pathCost(vertices)
+}
+
+// pathCost totals the weights of the edges along the vertices.
+func (g *Digraph[V]) pathCost(vertices []V) Path[V] {
+	path := Path[V]{Vertices: vertices}
+	for i := 1; i < len(vertices); i++ {
+		weight, _ := g.Edge(vertices[i-1], vertices[i])
+		path.Cost += weight
+	}
+	return path
+}
+
+// TopologicalSort orders the vertices so that every edge leads from a vertex to
+// one later in the order. Vertices that are not ordered by the edges keep the
+// order they were added in. It returns ErrCycle if there is no such order.
+func (g *Digraph[V]) TopologicalSort() ([]V, error) {
+	incoming := make(map[V]int, len(g.vertices))
+	for _, v := range g.vertices {
+		for _, e := range g.edges[v] {
+			incoming[e.To]++
+		}
+	}
+
+	var ready []V
+	for _, v := range g.vertices {
+		if incoming[v] == 0 {
+			ready = append(ready, v)
+		}
+	}
+
+	sorted := make([]V, 0, len(g.vertices))
+	for len(ready) > 0 {
+		v := ready[0]
+		ready = ready[1:]
+		sorted = append(sorted, v)
+		for _, e := range g.edges[v] {
+			if incoming[e.To]--; incoming[e.To] == 0 {
+				ready = append(ready, e.To)
+			}
+		}
+	}
+
+	if len(sorted) < len(g.vertices) {
+		return nil, ErrCycle
+	}
+	return sorted, nil
+}
+
+// StronglyConnectedComponents groups the vertices into sets where every vertex
+// can reach every other. The components are returned in reverse topological
+// order, so no edge leads from a component to an earlier one.
+func (g *Digraph[V]) StronglyConnectedComponents() [][]V {
+	// Tarjan's algorithm
+	var (
+		components [][]V
+		stack      []V
+		onStack    = make(map[V]bool)
+		index      = make(map[V]int)
+		lowLink    = make(map[V]int)
+		connect    func(v V)
+	)
+
+	connect = func(v V) {
+		index[v] = len(index)
+		lowLink[v] = index[v]
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, e := range g.edges[v] {
+			if _, visited := index[e.To]; !visited {
+				connect(e.To)
+				lowLink[v] = min(lowLink[v], lowLink[e.To])
+			} else if onStack[e.To] {
+				lowLink[v] = min(lowLink[v], index[e.To])
+			}
+		}
+
+		if lowLink[v] == index[v] {
+			var component []V
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			slices.Reverse(component)
+			components = append(components, component)
+		}
+	}
+
+	for _, v := range g.vertices {
+		if _, visited := index[v]; !visited {
+			connect(v)
+		}
+	}
+	return components
+}
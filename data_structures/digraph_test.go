@@ -0,0 +1,196 @@
+package data
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// newTestDigraph builds the graph
+//
+//	a -1-> b -1-> d -1-> e
+//	a -5-> c -1-> e
+//	b -1-> c
+func newTestDigraph() *Digraph[string] {
+	g := NewDigraph[string]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "c", 5)
+	g.AddEdge("b", "d", 1)
+	g.AddEdge("b", "c", 1)
+	g.AddEdge("c", "e", 1)
+	g.AddEdge("d", "e", 1)
+	return g
+}
+
+func TestDigraph_AddEdge(t *testing.T) {
+	g := NewDigraph[string]()
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "b", 3)
+	g.AddUndirectedEdge("b", "c", 2)
+
+	tests := []struct {
+		from, to   string
+		wantWeight int
+		wantFound  bool
+	}{
+		{"a", "b", 3, true},
+		{"b", "a", 0, false},
+		{"b", "c", 2, true},
+		{"c", "b", 2, true},
+	}
+	for _, tt := range tests {
+		if weight, found := g.Edge(tt.from, tt.to); weight != tt.wantWeight || found != tt.wantFound {
+			t.Errorf("Edge(%s, %s) = (%d, %v), want (%d, %v)", tt.from, tt.to, weight, found, tt.wantWeight, tt.wantFound)
+		}
+	}
+	if !reflect.DeepEqual(g.Vertices(), []string{"a", "b", "c"}) {
+		t.Errorf("Vertices() = %v, want [a b c]", g.Vertices())
+	}
+}
+
+func TestDigraph_Searches(t *testing.T) {
+	g := newTestDigraph()
+
+	tests := []struct {
+		name   string
+		search func(start, goal string) (Path[string], bool)
+		want   Path[string]
+	}{
+		{"BFS takes the fewest edges", g.BFS, Path[string]{[]string{"a", "c", "e"}, 6}},
+		{"DFS follows the first edges", g.DFS, Path[string]{[]string{"a", "b", "d", "e"}, 3}},
+		{"Dijkstra takes the lowest weight", g.Dijkstra, Path[string]{[]string{"a", "b", "d", "e"}, 3}},
+		{"AStar takes the lowest weight", func(start, goal string) (Path[string], bool) {
+			return g.AStar(start, goal, func(v string) int { return int(goal[0] - v[0]) })
+		}, Path[string]{[]string{"a", "b", "d", "e"}, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := tt.search("a", "e")
+			if !found || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("search = (%v, %v), want (%v, true)", got, found, tt.want)
+			}
+			if got, found := tt.search("e", "a"); found {
+				t.Errorf("Expected no path back from e to a, got %v", got)
+			}
+			if got, found := tt.search("a", "a"); !found || len(got.Vertices) != 1 || got.Cost != 0 {
+				t.Errorf("search from a to itself = (%v, %v), want just a", got, found)
+			}
+		})
+	}
+}
+
+func TestDigraph_Dijkstra_Grid(t *testing.T) {
+	// a grid of risk levels, where entering each cell costs its risk
+	risk := [][]int{
+		{1, 1, 6, 3},
+		{1, 3, 8, 1},
+		{2, 1, 3, 6},
+		{3, 6, 9, 4},
+	}
+	type cell struct{ row, col int }
+	g := NewDigraph[cell]()
+	for r, row := range risk {
+		for c := range row {
+			for _, n := range []cell{{r - 1, c}, {r + 1, c}, {r, c - 1}, {r, c + 1}} {
+				if n.row >= 0 && n.row < len(risk) && n.col >= 0 && n.col < len(row) {
+					g.AddEdge(cell{r, c}, n, risk[n.row][n.col])
+				}
+			}
+		}
+	}
+
+	goal := cell{3, 3}
+	manhattan := func(c cell) int { return goal.row - c.row + goal.col - c.col }
+	for name, path := range map[string]func() (Path[cell], bool){
+		"Dijkstra": func() (Path[cell], bool) { return g.Dijkstra(cell{0, 0}, goal) },
+		"AStar":    func() (Path[cell], bool) { return g.AStar(cell{0, 0}, goal, manhattan) },
+	} {
+		if got, _ := path(); got.Cost != 17 {
+			t.Errorf("%s cost = %d, want 17, path %v", name, got.Cost, got.Vertices)
+		}
+	}
+}
+
+func TestDigraph_AStar_InconsistentHeuristic(t *testing.T) {
+	// s -1-> a -1-> c -5-> g
+	// s -4-> c
+	g := NewDigraph[string]()
+	g.AddEdge("s", "a", 1)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("s", "c", 4)
+	g.AddEdge("c", "g", 5)
+
+	// the estimate for a is exact, but drops by more than the cost of going on
+	// to c, so c is searched from before the cheaper way to it through a is found
+	heuristic := func(v string) int {
+		if v == "a" {
+			return 6
+		}
+		return 0
+	}
+	want := Path[string]{[]string{"s", "a", "c", "g"}, 7}
+	if got, found := g.AStar("s", "g", heuristic); !found || !reflect.DeepEqual(got, want) {
+		t.Errorf("AStar() = (%v, %v), want (%v, true)", got, found, want)
+	}
+}
+
+func TestDigraph_Traversals(t *testing.T) {
+	g := newTestDigraph()
+
+	var breadth []string
+	var depths []int
+	for v, depth := range g.BreadthFirst("a") {
+		breadth = append(breadth, v)
+		depths = append(depths, depth)
+	}
+	if !reflect.DeepEqual(breadth, []string{"a", "b", "c", "d", "e"}) || !reflect.DeepEqual(depths, []int{0, 1, 1, 2, 2}) {
+		t.Errorf("BreadthFirst() = %v at depths %v", breadth, depths)
+	}
+
+	if got := slices.Collect(g.DepthFirst("a")); !reflect.DeepEqual(got, []string{"a", "b", "d", "e", "c"}) {
+		t.Errorf("DepthFirst() = %v, want [a b d e c]", got)
+	}
+}
+
+func TestDigraph_TopologicalSort(t *testing.T) {
+	g := newTestDigraph()
+	g.AddVertex("z")
+
+	got, err := g.TopologicalSort()
+	if err != nil {
+		t.Fatalf("TopologicalSort() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"a", "z", "b", "d", "c", "e"}) {
+		t.Errorf("TopologicalSort() = %v, want [a z b d c e]", got)
+	}
+
+	g.AddEdge("e", "a", 1)
+	if _, err := g.TopologicalSort(); !errors.Is(err, ErrCycle) {
+		t.Errorf("TopologicalSort() error = %v, want ErrCycle", err)
+	}
+}
+
+func TestDigraph_StronglyConnectedComponents(t *testing.T) {
+	g := NewDigraph[int]()
+	for _, e := range [][2]int{{1, 2}, {2, 3}, {3, 1}, {3, 4}, {4, 5}, {5, 4}, {6, 5}} {
+		g.AddEdge(e[0], e[1], 1)
+	}
+
+	want := [][]int{{4, 5}, {1, 2, 3}, {6}}
+	if got := g.StronglyConnectedComponents(); !reflect.DeepEqual(got, want) {
+		t.Errorf("StronglyConnectedComponents() = %v, want %v", got, want)
+	}
+}
+
+func TestDigraph_Subgraph(t *testing.T) {
+	g := newTestDigraph()
+	sub := g.Subgraph(func(v string) bool { return v != "b" })
+
+	if sub.HasVertex("b") || !reflect.DeepEqual(sub.Vertices(), []string{"a", "c", "d", "e"}) {
+		t.Errorf("Subgraph().Vertices() = %v, want [a c d e]", sub.Vertices())
+	}
+	if got, _ := sub.Dijkstra("a", "e"); got.Cost != 6 {
+		t.Errorf("Expected the path around b to cost 6, got %v", got)
+	}
+}
@@ -1,5 +1,13 @@
 package data
 
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
 // GenericTree is a generic, idiomatic tree structure.
 type GenericTree[T any] struct {
 	Root *GenericTreeNode[T]
@@ -32,3 +40,180 @@ func (n *GenericTreeNode[T]) Path() []*GenericTreeNode[T] {
 	}
 	return path
 }
+
+// ErrMoveBeneathItself is returned when a node would be moved beneath itself or
+// one of its own descendants, which would cut the subtree off from the tree.
+var ErrMoveBeneathItself = errors.New("cannot move a node beneath itself")
+
+// ErrNoParent is returned when a node would be moved beneath a nil parent. Use
+// Remove to detach a node from the tree.
+var ErrNoParent = errors.New("cannot move a node beneath a nil parent")
+
+// IsLeaf reports whether the node has no children.
+func (n *GenericTreeNode[T]) IsLeaf() bool {
+	return len(n.Children) == 0
+}
+
+// Depth returns the number of edges between the node and the root.
+func (n *GenericTreeNode[T]) Depth() int {
+	depth := 0
+	for curr := n.Parent; curr != nil; curr = curr.Parent {
+		depth++
+	}
+	return depth
+}
+
+// Height returns the number of edges on the longest path from the node down to
+// a leaf.
+func (n *GenericTreeNode[T]) Height() int {
+	return Fold(n, func(_ *GenericTreeNode[T], children []int) int {
+		height := 0
+		for _, h := range children {
+			height = max(height, h+1)
+		}
+		return height
+	})
+}
+
+// PreOrder iterates over the subtree from the node, visiting each node before
+// its children.
+func (n *GenericTreeNode[T]) PreOrder() iter.Seq[*GenericTreeNode[T]] {
+	return func(yield func(*GenericTreeNode[T]) bool) {
+		n.preOrder(yield)
+	}
+}
+
+func (n *GenericTreeNode[T]) preOrder(yield func(*GenericTreeNode[T]) bool) bool {
+	if !yield(n) {
+		return false
+	}
+	for _, child := range n.Children {
+		if !child.preOrder(yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// PostOrder iterates over the subtree from the node, visiting each node after
+// its children.
+func (n *GenericTreeNode[T]) PostOrder() iter.Seq[*GenericTreeNode[T]] {
+	return func(yield func(*GenericTreeNode[T]) bool) {
+		n.postOrder(yield)
+	}
+}
+
+func (n *GenericTreeNode[T]) postOrder(yield func(*GenericTreeNode[T]) bool) bool {
+	for _, child := range n.Children {
+		if !child.postOrder(yield) {
+			return false
+		}
+	}
+	return yield(n)
+}
+
+// LevelOrder iterates over the subtree from the node a level at a time, nearest
+// the node first.
+func (n *GenericTreeNode[T]) LevelOrder() iter.Seq[*GenericTreeNode[T]] {
+	return func(yield func(*GenericTreeNode[T]) bool) {
+		queue := NewDeque[*GenericTreeNode[T]]()
+		queue.PushBack(n)
+		for {
+			node, found := queue.PopFront()
+			if !found || !yield(node) {
+				return
+			}
+			for _, child := range node.Children {
+				queue.PushBack(child)
+			}
+		}
+	}
+}
+
+// Find returns the first node of the subtree, in pre-order, that matches.
+func (n *GenericTreeNode[T]) Find(match func(*GenericTreeNode[T]) bool) (*GenericTreeNode[T], bool) {
+	for node := range n.PreOrder() {
+		if match(node) {
+			return node, true
+		}
+	}
+	return nil, false
+}
+
+// FindAll returns every node of the subtree that matches, in pre-order.
+func (n *GenericTreeNode[T]) FindAll(match func(*GenericTreeNode[T]) bool) []*GenericTreeNode[T] {
+	var found []*GenericTreeNode[T]
+	for node := range n.PreOrder() {
+		if match(node) {
+			found = append(found, node)
+		}
+	}
+	return found
+}
+
+// Fold aggregates the subtree from the node bottom up, combining each node
+// with the results already folded from its children.
+func Fold[T, A any](n *GenericTreeNode[T], combine func(node *GenericTreeNode[T], children []A) A) A {
+	children := make([]A, len(n.Children))
+	for i, child := range n.Children {
+		children[i] = Fold(child, combine)
+	}
+	return combine(n, children)
+}
+
+// Remove detaches the node, along with its subtree, from its parent. It reports
+// false if the node has no parent.
+func (n *GenericTreeNode[T]) Remove() bool {
+	if n.Parent == nil {
+		return false
+	}
+	n.Parent.Children = slices.DeleteFunc(n.Parent.Children, func(c *GenericTreeNode[T]) bool { return c == n })
+	n.Parent = nil
+	return true
+}
+
+// Reparent moves the node, along with its subtree, to be the last child of the
+// new parent. The node is left where it is when the move fails.
+func (n *GenericTreeNode[T]) Reparent(parent *GenericTreeNode[T]) error {
+	if parent == nil {
+		return ErrNoParent
+	}
+	for curr := parent; curr != nil; curr = curr.Parent {
+		if curr == n {
+			return ErrMoveBeneathItself
+		}
+	}
+	n.Remove()
+	n.Parent = parent
+	parent.Children = append(parent.Children, n)
+	return nil
+}
+
+// Format draws the subtree from the node a node per line, with lines showing
+// how the nodes are connected. Each node is labelled by the label function, or
+// as it is formatted by fmt.Print when label is nil.
+func (n *GenericTreeNode[T]) Format(label func(T) string) string {
+	if label == nil {
+		label = func(v T) string { return fmt.Sprint(v) }
+	}
+	var b strings.Builder
+	b.WriteString(label(n.Value))
+	b.WriteByte('\n')
+	n.formatChildren(&b, "", label)
+	return b.String()
+}
+
+func (n *GenericTreeNode[T]) formatChildren(b *strings.Builder, indent string, label func(T) string) {
+	for i, child := range n.Children {
+		branch, nextIndent := "├── ", indent+"│   "
+		if i == len(n.Children)-1 {
+			branch, nextIndent = "└── ", indent+"    "
+		}
+		b.WriteString(indent + branch + label(child.Value) + "\n")
+		child.formatChildren(b, nextIndent, label)
+	}
+}
+
+func (t *GenericTree[T]) String() string {
+	return t.Root.Format(nil)
+}
@@ -1,6 +1,12 @@
 package data
 
-import "testing"
+import (
+	"errors"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+)
 
 func TestGenericTree_Basic(t *testing.T) {
 	tree := NewGenericTree("root")
@@ -38,3 +44,166 @@ func TestGenericTree_Basic(t *testing.T) {
 		t.Errorf("Path incorrect, got values: %v, want: [root child1 grandchild]", []string{path[0].Value, path[1].Value, path[2].Value})
 	}
 }
+
+// newTestTree builds the tree:
+//
+//	a
+//	├── b
+//	│   ├── d
+//	│   └── e
+//	│       └── g
+//	└── c
+//	    └── f
+func newTestTree() (*GenericTree[string], map[string]*GenericTreeNode[string]) {
+	tree := NewGenericTree("a")
+	nodes := map[string]*GenericTreeNode[string]{"a": tree.Root}
+	for _, edge := range [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"b", "e"}, {"c", "f"}, {"e", "g"}} {
+		nodes[edge[1]] = nodes[edge[0]].AddChild(edge[1])
+	}
+	return tree, nodes
+}
+
+func values(seq iter.Seq[*GenericTreeNode[string]]) string {
+	var s strings.Builder
+	for node := range seq {
+		s.WriteString(node.Value)
+	}
+	return s.String()
+}
+
+func TestGenericTree_Traversals(t *testing.T) {
+	tree, nodes := newTestTree()
+
+	tests := []struct {
+		name string
+		seq  iter.Seq[*GenericTreeNode[string]]
+		want string
+	}{
+		{"pre-order", tree.Root.PreOrder(), "abdegcf"},
+		{"post-order", tree.Root.PostOrder(), "dgebfca"},
+		{"level-order", tree.Root.LevelOrder(), "abcdefg"},
+		{"pre-order of a subtree", nodes["b"].PreOrder(), "bdeg"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := values(tt.seq); got != tt.want {
+				t.Errorf("Visited %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// stopping early, which panics if the iterator carries on calling yield
+	for _, seq := range []iter.Seq[*GenericTreeNode[string]]{tree.Root.PreOrder(), tree.Root.PostOrder(), tree.Root.LevelOrder()} {
+		count := 0
+		for range seq {
+			if count++; count == 3 {
+				break
+			}
+		}
+	}
+}
+
+func TestGenericTree_Search(t *testing.T) {
+	tree, nodes := newTestTree()
+
+	if node, found := tree.Root.Find(func(n *GenericTreeNode[string]) bool { return n.Value > "d" }); !found || node != nodes["e"] {
+		t.Errorf("Find() = %v, want e", node)
+	}
+	if _, found := nodes["c"].Find(func(n *GenericTreeNode[string]) bool { return n.Value == "g" }); found {
+		t.Error("Expected Find() to search only the subtree")
+	}
+	leaves := tree.Root.FindAll(func(n *GenericTreeNode[string]) bool { return n.IsLeaf() })
+	if got := values(slices.Values(leaves)); got != "dgf" {
+		t.Errorf("FindAll() = %s, want dgf", got)
+	}
+
+	tests := []struct {
+		node                  string
+		wantDepth, wantHeight int
+	}{
+		{"a", 0, 3},
+		{"b", 1, 2},
+		{"c", 1, 1},
+		{"g", 3, 0},
+	}
+	for _, tt := range tests {
+		if got := nodes[tt.node].Depth(); got != tt.wantDepth {
+			t.Errorf("%s.Depth() = %d, want %d", tt.node, got, tt.wantDepth)
+		}
+		if got := nodes[tt.node].Height(); got != tt.wantHeight {
+			t.Errorf("%s.Height() = %d, want %d", tt.node, got, tt.wantHeight)
+		}
+	}
+}
+
+func TestGenericTree_Fold(t *testing.T) {
+	tree, nodes := newTestTree()
+
+	size := func(_ *GenericTreeNode[string], children []int) int {
+		total := 1
+		for _, c := range children {
+			total += c
+		}
+		return total
+	}
+	if got := Fold(tree.Root, size); got != 7 {
+		t.Errorf("Fold() of the subtree sizes = %d, want 7", got)
+	}
+	if got := Fold(nodes["b"], size); got != 4 {
+		t.Errorf("Fold() of the subtree sizes from b = %d, want 4", got)
+	}
+}
+
+func TestGenericTree_Mutation(t *testing.T) {
+	tree, nodes := newTestTree()
+
+	if err := nodes["b"].Reparent(nodes["g"]); !errors.Is(err, ErrMoveBeneathItself) {
+		t.Errorf("Reparent() beneath itself error = %v, want %v", err, ErrMoveBeneathItself)
+	}
+	if err := nodes["e"].Reparent(nil); !errors.Is(err, ErrNoParent) {
+		t.Errorf("Reparent() to nil error = %v, want %v", err, ErrNoParent)
+	}
+	if nodes["e"].Parent != nodes["b"] {
+		t.Error("Expected e to stay beneath b when it cannot be moved")
+	}
+	if err := nodes["e"].Reparent(nodes["f"]); err != nil {
+		t.Fatalf("Reparent() error = %v", err)
+	}
+	if got := values(tree.Root.PreOrder()); got != "abdcfeg" {
+		t.Errorf("After moving e beneath f, visited %s, want abdcfeg", got)
+	}
+	if nodes["e"].Parent != nodes["f"] || nodes["g"].Depth() != 4 {
+		t.Error("Expected e and its subtree to move beneath f")
+	}
+
+	if !nodes["c"].Remove() || nodes["c"].Parent != nil {
+		t.Error("Expected c to be removed from its parent")
+	}
+	if got := values(tree.Root.PreOrder()); got != "abd" {
+		t.Errorf("After removing c, visited %s, want abd", got)
+	}
+	if got := values(nodes["c"].PreOrder()); got != "cfeg" {
+		t.Errorf("Expected the removed subtree to stay whole, visited %s", got)
+	}
+	if tree.Root.Remove() {
+		t.Error("Expected the root not to be removed")
+	}
+}
+
+func TestGenericTree_String(t *testing.T) {
+	tree, _ := newTestTree()
+	want := `a
+├── b
+│   ├── d
+│   └── e
+│       └── g
+└── c
+    └── f
+`
+	if got := tree.String(); got != want {
+		t.Errorf("String() =\n%s\nwant\n%s", got, want)
+	}
+	if got := tree.Root.Children[1].Format(strings.ToUpper); got != "C\n└── F\n" {
+		t.Errorf("Format() = %q", got)
+	}
+}
@@ -7,7 +7,8 @@ type Node struct {
 	Value interface{}
 }
 
-// Graph represents a graph of Nodes data elements
+// Graph represents a graph of Nodes data elements, linked both ways without
+// weights. Digraph has directed, weighted edges and search algorithms.
 type Graph struct {
 	Root  *Node
 	Nodes map[string]*Node
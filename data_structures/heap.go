@@ -0,0 +1,155 @@
+package data
+
+import "cmp"
+
+// Heap is a binary heap, or priority queue, ordered by a less function: the
+// item at the top is one for which less reports true against every other item.
+type Heap[T any] struct {
+	items []*HeapItem[T]
+	less  func(a, b T) bool
+}
+
+// HeapItem is a handle to an item pushed onto a heap, which can be used to
+// change the item's priority while it is on the heap.
+type HeapItem[T any] struct {
+	Value T
+	index int
+}
+
+func NewHeap[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// NewMinHeap returns a heap with the smallest item at the top.
+func NewMinHeap[T cmp.Ordered]() *Heap[T] {
+	return NewHeap(cmp.Less[T])
+}
+
+// NewMaxHeap returns a heap with the largest item at the top.
+func NewMaxHeap[T cmp.Ordered]() *Heap[T] {
+	return NewHeap(func(a, b T) bool { return cmp.Less(b, a) })
+}
+
+// NewHeapFromSlice returns a heap of the items, built in linear time, along
+// with the handle of each item in the order of items.
+func NewHeapFromSlice[T any](items []T, less func(a, b T) bool) (*Heap[T], []*HeapItem[T]) {
+	h := &Heap[T]{items: make([]*HeapItem[T], len(items)), less: less}
+	handles := make([]*HeapItem[T], len(items))
+	for i, value := range items {
+		handles[i] = &HeapItem[T]{Value: value, index: i}
+		h.items[i] = handles[i]
+	}
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h, handles
+}
+
+// Push adds the value to the heap, returning its handle.
+func (h *Heap[T]) Push(value T) *HeapItem[T] {
+	item := &HeapItem[T]{Value: value, index: len(h.items)}
+	h.items = append(h.items, item)
+	h.up(item.index)
+	return item
+}
+
+// Pop removes and returns the item at the top of the heap.
+func (h *Heap[T]) Pop() (value T, found bool) {
+	if len(h.items) == 0 {
+		return value, false
+	}
+	return h.remove(0).Value, true
+}
+
+// Peek returns the item at the top of the heap without removing it.
+func (h *Heap[T]) Peek() (value T, found bool) {
+	if len(h.items) == 0 {
+		return value, false
+	}
+	return h.items[0].Value, true
+}
+
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// Update changes the value of an item on the heap, such as lowering its
+// distance in a shortest path search, and moves it to its new place. It reports
+// whether the item was still on the heap, and leaves it unchanged when it was not.
+func (h *Heap[T]) Update(item *HeapItem[T], value T) bool {
+	if !h.contains(item) {
+		return false
+	}
+	item.Value = value
+	if !h.up(item.index) {
+		h.down(item.index)
+	}
+	return true
+}
+
+// Remove takes the item off the heap, reporting whether it was still on it.
+func (h *Heap[T]) Remove(item *HeapItem[T]) bool {
+	if !h.contains(item) {
+		return false
+	}
+	h.remove(item.index)
+	return true
+}
+
+// contains reports whether the handle is for an item on this heap, rather than
+// one that has been popped or removed, or belongs to another heap.
+func (h *Heap[T]) contains(item *HeapItem[T]) bool {
+	return item.index >= 0 && item.index < len(h.items) && h.items[item.index] == item
+}
+
+func (h *Heap[T]) remove(i int) *HeapItem[T] {
+	item := h.items[i]
+	last := len(h.items) - 1
+	h.swap(i, last)
+	h.items[last] = nil
+	h.items = h.items[:last]
+	if i < last && !h.up(i) {
+		h.down(i)
+	}
+	item.index = -1
+	return item
+}
+
+// up moves the item at i towards the top until it is in order, and reports
+// whether it moved.
+func (h *Heap[T]) up(i int) bool {
+	start := i
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i].Value, h.items[parent].Value) {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+	return i != start
+}
+
+// down moves the item at i towards the bottom until it is in order.
+func (h *Heap[T]) down(i int) {
+	for {
+		smallest := i
+		if left := 2*i + 1; left < len(h.items) && h.less(h.items[left].Value, h.items[smallest].Value) {
+			smallest = left
+		}
+		if right := 2*i + 2; right < len(h.items) && h.less(h.items[right].Value, h.items[smallest].Value) {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
@@ -0,0 +1,202 @@
+package data
+
+import (
+	"container/heap"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+// popAll empties the heap, returning the items in the order they were popped.
+func popAll[T any](h *Heap[T]) []T {
+	var result []T
+	for {
+		value, found := h.Pop()
+		if !found {
+			return result
+		}
+		result = append(result, value)
+	}
+}
+
+func TestHeap_PushPop(t *testing.T) {
+	tests := []struct {
+		name  string
+		heap  *Heap[int]
+		items []int
+		want  []int
+	}{
+		{"Min heap", NewMinHeap[int](), []int{5, 3, 8, 1, 9, 1}, []int{1, 1, 3, 5, 8, 9}},
+		{"Max heap", NewMaxHeap[int](), []int{5, 3, 8, 1, 9, 1}, []int{9, 8, 5, 3, 1, 1}},
+		{"Custom less", NewHeap(func(a, b int) bool { return a%10 < b%10 }), []int{19, 21, 35}, []int{21, 35, 19}},
+		{"Empty", NewMinHeap[int](), nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, item := range tt.items {
+				tt.heap.Push(item)
+			}
+			if tt.heap.Len() != len(tt.items) {
+				t.Errorf("Heap.Len() = %d, want %d", tt.heap.Len(), len(tt.items))
+			}
+			if len(tt.want) > 0 {
+				if top, found := tt.heap.Peek(); top != tt.want[0] || !found {
+					t.Errorf("Heap.Peek() = (%v, %v), want (%v, true)", top, found, tt.want[0])
+				}
+			}
+			if got := popAll(tt.heap); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("popped %v, want %v", got, tt.want)
+			}
+			if _, found := tt.heap.Peek(); found {
+				t.Error("Expected Peek to find nothing on an empty heap")
+			}
+		})
+	}
+}
+
+func TestNewHeapFromSlice(t *testing.T) {
+	items := rand.New(rand.NewSource(1)).Perm(100)
+	h, handles := NewHeapFromSlice(items, func(a, b int) bool { return a < b })
+
+	for i, item := range handles {
+		if item.Value != items[i] {
+			t.Fatalf("handles[%d] holds %d, want %d", i, item.Value, items[i])
+		}
+	}
+	// move the largest item to the top through its handle
+	largest := slices.Index(items, 99)
+	if !h.Update(handles[largest], -1) {
+		t.Fatal("Expected the handle to be for an item on the heap")
+	}
+	if top, _ := h.Peek(); top != -1 {
+		t.Fatalf("Peek() = %d after Update, want -1", top)
+	}
+	h.Update(handles[largest], 99)
+
+	got := popAll(h)
+	if !slices.IsSorted(got) || len(got) != 100 {
+		t.Errorf("popped %v, want 0 to 99 in order", got)
+	}
+}
+
+type vertex struct {
+	name     string
+	distance int
+}
+
+func TestHeap_Update(t *testing.T) {
+	h := NewHeap(func(a, b vertex) bool { return a.distance < b.distance })
+	a := h.Push(vertex{"a", 10})
+	b := h.Push(vertex{"b", 20})
+	h.Push(vertex{"c", 15})
+
+	// decrease-key, as when a shorter path to b is found
+	h.Update(b, vertex{"b", 5})
+	if top, _ := h.Peek(); top.name != "b" {
+		t.Errorf("Heap.Peek() = %v, want b after lowering its distance", top)
+	}
+	h.Update(a, vertex{"a", 30})
+
+	var names []string
+	for _, v := range popAll(h) {
+		names = append(names, v.name)
+	}
+	if !reflect.DeepEqual(names, []string{"b", "c", "a"}) {
+		t.Errorf("popped %v, want [b c a]", names)
+	}
+}
+
+func TestHeap_Remove(t *testing.T) {
+	h := NewMinHeap[int]()
+	var handles []*HeapItem[int]
+	for _, item := range []int{4, 2, 7, 1, 9} {
+		handles = append(handles, h.Push(item))
+	}
+
+	if !h.Remove(handles[2]) {
+		t.Error("Expected Remove to find 7 on the heap")
+	}
+	if h.Remove(handles[2]) {
+		t.Error("Expected Remove to report 7 was already removed")
+	}
+	if got := popAll(h); !reflect.DeepEqual(got, []int{1, 2, 4, 9}) {
+		t.Errorf("popped %v, want [1 2 4 9]", got)
+	}
+	if h.Remove(handles[0]) {
+		t.Error("Expected Remove to report 4 was already popped")
+	}
+}
+
+func TestHeap_UpdateStaleHandle(t *testing.T) {
+	h := NewMinHeap[int]()
+	popped := h.Push(1)
+	h.Push(5)
+	h.Pop()
+
+	if h.Update(popped, 0) {
+		t.Error("Expected Update to report 1 was already popped")
+	}
+	if popped.Value != 1 {
+		t.Errorf("Update changed a popped item to %d", popped.Value)
+	}
+	if got := popAll(h); !reflect.DeepEqual(got, []int{5}) {
+		t.Errorf("popped %v, want [5]", got)
+	}
+}
+
+const benchmarkHeapItems = 100_000
+
+func BenchmarkHeap_PushPop(b *testing.B) {
+	items := rand.New(rand.NewSource(1)).Perm(benchmarkHeapItems)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h := NewMinHeap[int]()
+		for _, item := range items {
+			h.Push(item)
+		}
+		for h.Len() > 0 {
+			h.Pop()
+		}
+	}
+}
+
+func BenchmarkNewHeapFromSlice(b *testing.B) {
+	items := rand.New(rand.NewSource(1)).Perm(benchmarkHeapItems)
+	less := func(a, b int) bool { return a < b }
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h, _ := NewHeapFromSlice(items, less)
+		for h.Len() > 0 {
+			h.Pop()
+		}
+	}
+}
+
+// intHeap is the container/heap boilerplate that Heap replaces, for comparison.
+type intHeap []int
+
+func (h intHeap) Len() int           { return len(h) }
+func (h intHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h intHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *intHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *intHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+func BenchmarkContainerHeap_PushPop(b *testing.B) {
+	items := rand.New(rand.NewSource(1)).Perm(benchmarkHeapItems)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h := &intHeap{}
+		for _, item := range items {
+			heap.Push(h, item)
+		}
+		for h.Len() > 0 {
+			heap.Pop(h)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package data
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"sort"
+)
+
+// Integer is any integer type that an interval can span.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Interval is the half-open range of integers from Start up to, but not
+// including, End. An interval whose End is not after its Start is empty.
+type Interval[T Integer] struct {
+	Start, End T
+}
+
+// NewInterval creates the half-open interval [start, end).
+func NewInterval[T Integer](start, end T) Interval[T] {
+	return Interval[T]{Start: start, End: end}
+}
+
+// InclusiveInterval creates the interval from first to last, including both.
+// The End of a half-open interval is one past its last value, so last must be
+// less than the largest value of T. It panics when last is the largest value,
+// rather than wrap round to an empty interval.
+func InclusiveInterval[T Integer](first, last T) Interval[T] {
+	end := last + 1
+	if end < last {
+		panic(fmt.Sprintf("data: InclusiveInterval cannot include %v, the largest value of %T", last, last))
+	}
+	return Interval[T]{Start: first, End: end}
+}
+
+// Empty reports whether the interval holds no values.
+func (iv Interval[T]) Empty() bool {
+	return iv.End <= iv.Start
+}
+
+// Len returns the number of values in the interval.
+func (iv Interval[T]) Len() T {
+	if iv.Empty() {
+		return 0
+	}
+	return iv.End - iv.Start
+}
+
+// Last returns the last value in the interval, the inclusive end.
+func (iv Interval[T]) Last() T {
+	return iv.End - 1
+}
+
+// Contains reports whether the value is in the interval.
+func (iv Interval[T]) Contains(v T) bool {
+	return iv.Start <= v && v < iv.End
+}
+
+// ContainsInterval reports whether every value of the other interval is in
+// this one. Every interval contains an empty interval.
+func (iv Interval[T]) ContainsInterval(other Interval[T]) bool {
+	return other.Empty() || (iv.Start <= other.Start && other.End <= iv.End)
+}
+
+// Overlaps reports whether the intervals have any value in common.
+func (iv Interval[T]) Overlaps(other Interval[T]) bool {
+	return !iv.Intersect(other).Empty()
+}
+
+// Intersect returns the values common to both intervals, which may be empty.
+func (iv Interval[T]) Intersect(other Interval[T]) Interval[T] {
+	return Interval[T]{Start: max(iv.Start, other.Start), End: min(iv.End, other.End)}
+}
+
+func (iv Interval[T]) String() string {
+	return fmt.Sprintf("[%v,%v)", iv.Start, iv.End)
+}
+
+// IntervalSet is a set of integers held as the fewest intervals that cover
+// them. Intervals that overlap or touch are merged as they are added. The zero
+// value is an empty set ready to use.
+type IntervalSet[T Integer] struct {
+	intervals []Interval[T] // sorted, non-empty, and separated by gaps
+}
+
+// NewIntervalSet creates a set holding the values of the intervals.
+func NewIntervalSet[T Integer](intervals ...Interval[T]) *IntervalSet[T] {
+	s := &IntervalSet[T]{}
+	for _, iv := range intervals {
+		s.Add(iv)
+	}
+	return s
+}
+
+// Add puts the values of the interval into the set.
+func (s *IntervalSet[T]) Add(iv Interval[T]) {
+	if iv.Empty() {
+		return
+	}
+	// the first interval that overlaps or touches iv, and the first one after it
+	// that is clear of iv
+	i := sort.Search(len(s.intervals), func(i int) bool { return s.intervals[i].End >= iv.Start })
+	j := i
+	for j < len(s.intervals) && s.intervals[j].Start <= iv.End {
+		iv.Start = min(iv.Start, s.intervals[j].Start)
+		iv.End = max(iv.End, s.intervals[j].End)
+		j++
+	}
+	s.intervals = slices.Replace(s.intervals, i, j, iv)
+}
+
+// Remove takes the values of the interval out of the set.
+func (s *IntervalSet[T]) Remove(iv Interval[T]) {
+	if iv.Empty() {
+		return
+	}
+	i := sort.Search(len(s.intervals), func(i int) bool { return s.intervals[i].End > iv.Start })
+	j := i
+	var remaining []Interval[T]
+	for j < len(s.intervals) && s.intervals[j].Start < iv.End {
+		if before := (Interval[T]{Start: s.intervals[j].Start, End: iv.Start}); !before.Empty() {
+			remaining = append(remaining, before)
+		}
+		if after := (Interval[T]{Start: iv.End, End: s.intervals[j].End}); !after.Empty() {
+			remaining = append(remaining, after)
+		}
+		j++
+	}
+	s.intervals = slices.Replace(s.intervals, i, j, remaining...)
+}
+
+// Union returns a set of the values in either set.
+func (s *IntervalSet[T]) Union(other *IntervalSet[T]) *IntervalSet[T] {
+	u := s.Copy()
+	for _, iv := range other.intervals {
+		u.Add(iv)
+	}
+	return u
+}
+
+// Intersect returns a set of the values in both sets.
+func (s *IntervalSet[T]) Intersect(other *IntervalSet[T]) *IntervalSet[T] {
+	result := &IntervalSet[T]{}
+	for i, j := 0, 0; i < len(s.intervals) && j < len(other.intervals); {
+		a, b := s.intervals[i], other.intervals[j]
+		if common := a.Intersect(b); !common.Empty() {
+			result.intervals = append(result.intervals, common)
+		}
+		// move past whichever interval ends first, as it can overlap nothing more
+		if a.End < b.End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return result
+}
+
+// Difference returns a set of the values in this set that are not in the
+// other.
+func (s *IntervalSet[T]) Difference(other *IntervalSet[T]) *IntervalSet[T] {
+	d := s.Copy()
+	for _, iv := range other.intervals {
+		d.Remove(iv)
+	}
+	return d
+}
+
+// Copy returns a set with the same values, which can be changed independently.
+func (s *IntervalSet[T]) Copy() *IntervalSet[T] {
+	return &IntervalSet[T]{intervals: slices.Clone(s.intervals)}
+}
+
+// Find returns the interval of the set that holds the value.
+func (s *IntervalSet[T]) Find(v T) (iv Interval[T], found bool) {
+	i := sort.Search(len(s.intervals), func(i int) bool { return s.intervals[i].End > v })
+	if i < len(s.intervals) && s.intervals[i].Contains(v) {
+		return s.intervals[i], true
+	}
+	return iv, false
+}
+
+// Contains reports whether the value is in the set.
+func (s *IntervalSet[T]) Contains(v T) bool {
+	_, found := s.Find(v)
+	return found
+}
+
+// ContainsInterval reports whether every value of the interval is in the set.
+func (s *IntervalSet[T]) ContainsInterval(iv Interval[T]) bool {
+	if iv.Empty() {
+		return true
+	}
+	holder, found := s.Find(iv.Start)
+	return found && holder.ContainsInterval(iv)
+}
+
+// Len returns the number of values in the set.
+func (s *IntervalSet[T]) Len() T {
+	var total T
+	for _, iv := range s.intervals {
+		total += iv.Len()
+	}
+	return total
+}
+
+// Intervals returns a copy of the intervals that make up the set, in order.
+func (s *IntervalSet[T]) Intervals() []Interval[T] {
+	return slices.Clone(s.intervals)
+}
+
+// All iterates over the intervals that make up the set, in order.
+func (s *IntervalSet[T]) All() iter.Seq[Interval[T]] {
+	return slices.Values(s.intervals)
+}
+
+func (s *IntervalSet[T]) String() string {
+	return fmt.Sprint(s.intervals)
+}
@@ -0,0 +1,155 @@
+package data
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestInterval(t *testing.T) {
+	iv := InclusiveInterval(2, 5)
+	if iv != NewInterval(2, 6) || iv.Len() != 4 || iv.Last() != 5 {
+		t.Errorf("InclusiveInterval(2, 5) = %v with length %d, want [2,6) with length 4", iv, iv.Len())
+	}
+	if !iv.Contains(2) || !iv.Contains(5) || iv.Contains(6) || iv.Contains(1) {
+		t.Errorf("Expected %v to contain 2 to 5 only", iv)
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected InclusiveInterval to panic when last is the largest value")
+			}
+		}()
+		InclusiveInterval[int8](0, 127)
+	}()
+	if empty := NewInterval(3, 3); !empty.Empty() || empty.Len() != 0 || empty.Contains(3) {
+		t.Errorf("Expected %v to be empty", empty)
+	}
+
+	tests := []struct {
+		name         string
+		a, b         Interval[int]
+		wantContains bool
+		wantOverlaps bool
+	}{
+		{"enclosed", NewInterval(1, 10), NewInterval(3, 5), true, true},
+		{"equal", NewInterval(1, 10), NewInterval(1, 10), true, true},
+		{"overlapping", NewInterval(1, 5), NewInterval(4, 8), false, true},
+		{"touching", NewInterval(1, 5), NewInterval(5, 8), false, false},
+		{"apart", NewInterval(1, 5), NewInterval(7, 8), false, false},
+		{"empty", NewInterval(1, 5), NewInterval(3, 3), true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.a.ContainsInterval(tt.b); got != tt.wantContains {
+				t.Errorf("%v.ContainsInterval(%v) = %v, want %v", tt.a, tt.b, got, tt.wantContains)
+			}
+			if got := tt.a.Overlaps(tt.b); got != tt.wantOverlaps {
+				t.Errorf("%v.Overlaps(%v) = %v, want %v", tt.a, tt.b, got, tt.wantOverlaps)
+			}
+			if got := tt.b.Overlaps(tt.a); got != tt.wantOverlaps {
+				t.Errorf("%v.Overlaps(%v) = %v, want %v", tt.b, tt.a, got, tt.wantOverlaps)
+			}
+		})
+	}
+}
+
+func TestIntervalSet_AddMerges(t *testing.T) {
+	tests := []struct {
+		name string
+		add  []Interval[int]
+		want []Interval[int]
+	}{
+		{"apart", []Interval[int]{{10, 12}, {1, 3}, {5, 7}}, []Interval[int]{{1, 3}, {5, 7}, {10, 12}}},
+		{"touching", []Interval[int]{{1, 3}, {3, 5}}, []Interval[int]{{1, 5}}},
+		{"bridging", []Interval[int]{{1, 3}, {5, 7}, {9, 11}, {2, 10}}, []Interval[int]{{1, 11}}},
+		{"enclosed", []Interval[int]{{1, 10}, {3, 4}}, []Interval[int]{{1, 10}}},
+		{"empty", []Interval[int]{{1, 3}, {5, 5}}, []Interval[int]{{1, 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewIntervalSet(tt.add...).Intervals(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Intervals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIntervalSet_Operations(t *testing.T) {
+	a := NewIntervalSet(NewInterval(0, 5), NewInterval(10, 15))
+	b := NewIntervalSet(NewInterval(3, 12), NewInterval(14, 20))
+
+	tests := []struct {
+		name string
+		got  *IntervalSet[int]
+		want []Interval[int]
+	}{
+		{"union", a.Union(b), []Interval[int]{{0, 20}}},
+		{"intersect", a.Intersect(b), []Interval[int]{{3, 5}, {10, 12}, {14, 15}}},
+		{"difference", a.Difference(b), []Interval[int]{{0, 3}, {12, 14}}},
+		{"reverse difference", b.Difference(a), []Interval[int]{{5, 10}, {15, 20}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.Intervals(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Intervals() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := a.Intervals(); !reflect.DeepEqual(got, []Interval[int]{{0, 5}, {10, 15}}) {
+		t.Errorf("Expected the operations to leave the set unchanged, got %v", got)
+	}
+	if a.Len() != 10 {
+		t.Errorf("Len() = %d, want 10", a.Len())
+	}
+	if iv, found := a.Find(12); !found || iv != NewInterval(10, 15) {
+		t.Errorf("Find(12) = %v, %v, want [10,15)", iv, found)
+	}
+	if a.Contains(5) || !a.Contains(4) {
+		t.Error("Expected 4 and not 5 to be in the set")
+	}
+	if !a.ContainsInterval(NewInterval(11, 15)) || a.ContainsInterval(NewInterval(4, 11)) {
+		t.Error("Expected [11,15) and not [4,11) to be in the set")
+	}
+}
+
+// TestIntervalSet_MatchesMap compares a set of random changes against a map of
+// the values they should leave in the set.
+func TestIntervalSet_MatchesMap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	s := &IntervalSet[int8]{}
+	values := make(map[int8]bool)
+
+	for range 1000 {
+		start := int8(rng.Intn(100) - 50)
+		iv := NewInterval(start, start+int8(rng.Intn(10)))
+		add := rng.Intn(3) > 0
+		if add {
+			s.Add(iv)
+		} else {
+			s.Remove(iv)
+		}
+		for v := iv.Start; v < iv.End; v++ {
+			values[v] = add
+		}
+
+		var count int8
+		for v := int8(-60); v < 60; v++ {
+			if s.Contains(v) != values[v] {
+				t.Fatalf("After changing %v, Contains(%d) = %v, want %v in %v", iv, v, s.Contains(v), values[v], s)
+			}
+			if values[v] {
+				count++
+			}
+		}
+		if s.Len() != count {
+			t.Fatalf("Len() = %d, want %d", s.Len(), count)
+		}
+		for i := 1; i < len(s.intervals); i++ {
+			if s.intervals[i-1].End >= s.intervals[i].Start {
+				t.Fatalf("Expected the intervals to be separated, got %v", s)
+			}
+		}
+	}
+}
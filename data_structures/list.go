@@ -0,0 +1,320 @@
+package data
+
+import (
+	"fmt"
+	"iter"
+)
+
+// ListNode is a value in a List, linked to the values either side of it.
+type ListNode[T any] struct {
+	Value      T
+	next, prev *ListNode[T]
+	list       *List[T]
+}
+
+// Next returns the node after this one, or nil at the back of the list.
+func (n *ListNode[T]) Next() *ListNode[T] {
+	if next := n.next; n.list != nil && next != &n.list.root {
+		return next
+	}
+	return nil
+}
+
+// Prev returns the node before this one, or nil at the front of the list.
+func (n *ListNode[T]) Prev() *ListNode[T] {
+	if prev := n.prev; n.list != nil && prev != &n.list.root {
+		return prev
+	}
+	return nil
+}
+
+// NextCircular returns the node after this one, wrapping round from the back
+// of the list to the front, as if the list were a circle.
+func (n *ListNode[T]) NextCircular() *ListNode[T] {
+	if next := n.Next(); next != nil {
+		return next
+	}
+	return n.list.Front()
+}
+
+// PrevCircular returns the node before this one, wrapping round from the front
+// of the list to the back, as if the list were a circle.
+func (n *ListNode[T]) PrevCircular() *ListNode[T] {
+	if prev := n.Prev(); prev != nil {
+		return prev
+	}
+	return n.list.Back()
+}
+
+// List is a doubly linked list, which can have values added, removed and moved
+// anywhere along it in constant time. The zero value is an empty list ready to
+// use.
+type List[T any] struct {
+	// root links the back of the list round to the front, so that the list is a
+	// ring and no insertion needs a special case for the ends.
+	root   ListNode[T]
+	length int
+}
+
+// NewList creates a list of the values, in order.
+func NewList[T any](values ...T) *List[T] {
+	l := &List[T]{}
+	for _, v := range values {
+		l.PushBack(v)
+	}
+	return l
+}
+
+func (l *List[T]) lazyInit() {
+	if l.root.next == nil {
+		l.root.next = &l.root
+		l.root.prev = &l.root
+	}
+}
+
+// Len returns the number of values in the list.
+func (l *List[T]) Len() int {
+	return l.length
+}
+
+// Front returns the first node of the list, or nil if the list is empty.
+func (l *List[T]) Front() *ListNode[T] {
+	if l.length == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last node of the list, or nil if the list is empty.
+func (l *List[T]) Back() *ListNode[T] {
+	if l.length == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// link puts the node into the list after at.
+func (l *List[T]) link(n, at *ListNode[T]) *ListNode[T] {
+	n.prev = at
+	n.next = at.next
+	n.prev.next = n
+	n.next.prev = n
+	n.list = l
+	l.length++
+	return n
+}
+
+// unlink takes the node out of the list.
+func (l *List[T]) unlink(n *ListNode[T]) {
+	n.prev.next = n.next
+	n.next.prev = n.prev
+	n.next, n.prev, n.list = nil, nil, nil
+	l.length--
+}
+
+// move takes the node out of where it is in the list and puts it after at.
+func (l *List[T]) move(n, at *ListNode[T]) {
+	if n == at || n == at.next {
+		return
+	}
+	n.prev.next = n.next
+	n.next.prev = n.prev
+
+	n.prev = at
+	n.next = at.next
+	n.prev.next = n
+	n.next.prev = n
+}
+
+// PushFront adds the value to the front of the list.
+func (l *List[T]) PushFront(v T) *ListNode[T] {
+	l.lazyInit()
+	return l.link(&ListNode[T]{Value: v}, &l.root)
+}
+
+// PushBack adds the value to the back of the list.
+func (l *List[T]) PushBack(v T) *ListNode[T] {
+	l.lazyInit()
+	return l.link(&ListNode[T]{Value: v}, l.root.prev)
+}
+
+// InsertBefore adds the value just before the mark, which must be in the list.
+func (l *List[T]) InsertBefore(v T, mark *ListNode[T]) *ListNode[T] {
+	l.mustContain(mark)
+	return l.link(&ListNode[T]{Value: v}, mark.prev)
+}
+
+// InsertAfter adds the value just after the mark, which must be in the list.
+func (l *List[T]) InsertAfter(v T, mark *ListNode[T]) *ListNode[T] {
+	l.mustContain(mark)
+	return l.link(&ListNode[T]{Value: v}, mark)
+}
+
+// Remove takes the node out of the list, returning its value. It reports false
+// if the node is not in the list.
+func (l *List[T]) Remove(n *ListNode[T]) (value T, found bool) {
+	if n == nil || n.list != l {
+		return value, false
+	}
+	l.unlink(n)
+	return n.Value, true
+}
+
+// MoveToFront moves the node, which must be in the list, to the front.
+func (l *List[T]) MoveToFront(n *ListNode[T]) {
+	l.mustContain(n)
+	l.move(n, &l.root)
+}
+
+// MoveToBack moves the node, which must be in the list, to the back.
+func (l *List[T]) MoveToBack(n *ListNode[T]) {
+	l.mustContain(n)
+	l.move(n, l.root.prev)
+}
+
+// MoveAfter moves the node to just after the mark. Both must be in the list.
+func (l *List[T]) MoveAfter(n, mark *ListNode[T]) {
+	l.mustContain(n)
+	l.mustContain(mark)
+	l.move(n, mark)
+}
+
+// MoveBefore moves the node to just before the mark. Both must be in the list.
+func (l *List[T]) MoveBefore(n, mark *ListNode[T]) {
+	l.mustContain(n)
+	l.mustContain(mark)
+	l.move(n, mark.prev)
+}
+
+func (l *List[T]) mustContain(n *ListNode[T]) {
+	if n == nil || n.list != l {
+		panic("list: node is not in the list")
+	}
+}
+
+// SpliceAfter moves every node of the other list to just after the mark, which
+// must be in this list, leaving the other list empty. The nodes are moved, not
+// copied, so they can still be used to reach the values.
+func (l *List[T]) SpliceAfter(mark *ListNode[T], other *List[T]) {
+	l.mustContain(mark)
+	l.splice(mark, other)
+}
+
+// SpliceBack moves every node of the other list to the back of this list,
+// leaving the other list empty.
+func (l *List[T]) SpliceBack(other *List[T]) {
+	l.lazyInit()
+	l.splice(l.root.prev, other)
+}
+
+func (l *List[T]) splice(at *ListNode[T], other *List[T]) {
+	if other == l {
+		panic("list: cannot splice a list into itself")
+	}
+	if other.length == 0 {
+		return
+	}
+	first, last := other.root.next, other.root.prev
+	for n := first; n != &other.root; n = n.next {
+		n.list = l
+	}
+
+	first.prev = at
+	last.next = at.next
+	at.next.prev = last
+	at.next = first
+	l.length += other.length
+
+	other.root.next, other.root.prev = &other.root, &other.root
+	other.length = 0
+}
+
+// Rotate turns the list as if it were a circle, so that the value n places
+// along from the front becomes the front. A negative n turns it the other way,
+// so that the value -n places back from the back becomes the front.
+func (l *List[T]) Rotate(n int) {
+	if l.length < 2 {
+		return
+	}
+	n = (n%l.length + l.length) % l.length
+	if n == 0 {
+		return
+	}
+
+	// find the new front, going whichever way round the circle is shorter
+	front := l.root.next
+	if n <= l.length/2 {
+		for range n {
+			front = front.next
+		}
+	} else {
+		front = l.root.prev
+		for range l.length - n - 1 {
+			front = front.prev
+		}
+	}
+
+	// take the root out of the ring, and put it back in just before the new front
+	l.root.prev.next = l.root.next
+	l.root.next.prev = l.root.prev
+	l.root.prev = front.prev
+	l.root.next = front
+	front.prev.next = &l.root
+	front.prev = &l.root
+}
+
+// All iterates over the values from the front of the list to the back.
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.Front(); n != nil; n = n.Next() {
+			if !yield(n.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward iterates over the values from the back of the list to the front.
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := l.Back(); n != nil; n = n.Prev() {
+			if !yield(n.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Nodes iterates over the nodes from the front of the list to the back. The
+// next node is found before each node is visited, so the node being visited
+// can be removed, and values inserted after it are not visited.
+func (l *List[T]) Nodes() iter.Seq[*ListNode[T]] {
+	return func(yield func(*ListNode[T]) bool) {
+		for n := l.Front(); n != nil; {
+			next := n.Next()
+			if !yield(n) {
+				return
+			}
+			n = next
+		}
+	}
+}
+
+// Values returns a copy of the values from the front of the list to the back.
+func (l *List[T]) Values() []T {
+	values := make([]T, 0, l.length)
+	for v := range l.All() {
+		values = append(values, v)
+	}
+	return values
+}
+
+// String returns the values as they are formatted by fmt.Print, or the text
+// they spell for a list of runes.
+func (l *List[T]) String() string {
+	values := l.Values()
+	if runes, ok := any(values).([]rune); ok {
+		return string(runes)
+	}
+	return fmt.Sprint(values)
+}
@@ -0,0 +1,178 @@
+package data
+
+import (
+	"slices"
+	"testing"
+)
+
+// checkList checks that the list links up the same way forwards and
+// backwards, and holds the values wanted.
+func checkList[T comparable](t *testing.T, l *List[T], want ...T) {
+	t.Helper()
+	if l.Len() != len(want) {
+		t.Errorf("Len() = %d, want %d", l.Len(), len(want))
+	}
+	if got := l.Values(); !slices.Equal(got, want) {
+		t.Errorf("Values() = %v, want %v", got, want)
+	}
+	backward := slices.Collect(l.Backward())
+	slices.Reverse(backward)
+	if !slices.Equal(backward, want) {
+		t.Errorf("Backward() = %v, want the reverse of %v", backward, want)
+	}
+}
+
+func TestList_Insertion(t *testing.T) {
+	var l List[int]
+	checkList(t, &l)
+	if l.Front() != nil || l.Back() != nil {
+		t.Error("Expected an empty list to have no front or back")
+	}
+
+	three := l.PushBack(3)
+	l.PushFront(1)
+	l.InsertBefore(2, three)
+	five := l.InsertAfter(5, three)
+	l.InsertBefore(4, five)
+	l.PushBack(6)
+	checkList(t, &l, 1, 2, 3, 4, 5, 6)
+
+	if v, found := l.Remove(three); !found || v != 3 {
+		t.Errorf("Remove() = %v, %v, want 3, true", v, found)
+	}
+	if _, found := l.Remove(three); found {
+		t.Error("Expected a node not to be removed twice")
+	}
+	if _, found := NewList(7).Remove(five); found {
+		t.Error("Expected a node not to be removed from another list")
+	}
+	checkList(t, &l, 1, 2, 4, 5, 6)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected inserting next to a removed node to panic")
+		}
+	}()
+	l.InsertAfter(0, three)
+}
+
+func TestList_Moves(t *testing.T) {
+	l := NewList(1, 2, 3, 4, 5)
+	nodes := slices.Collect(l.Nodes())
+
+	l.MoveToFront(nodes[3])
+	checkList(t, l, 4, 1, 2, 3, 5)
+	l.MoveToBack(nodes[0])
+	checkList(t, l, 4, 2, 3, 5, 1)
+	l.MoveAfter(nodes[3], nodes[4])
+	checkList(t, l, 2, 3, 5, 4, 1)
+	l.MoveBefore(nodes[0], nodes[1])
+	checkList(t, l, 1, 2, 3, 5, 4)
+	l.MoveBefore(nodes[0], nodes[1])
+	checkList(t, l, 1, 2, 3, 5, 4)
+	l.MoveAfter(nodes[2], nodes[2])
+	checkList(t, l, 1, 2, 3, 5, 4)
+}
+
+func TestList_Splice(t *testing.T) {
+	l := NewList(1, 2, 5)
+	other := NewList(3, 4)
+	moved := other.Front()
+
+	l.SpliceAfter(l.Front().Next(), other)
+	checkList(t, l, 1, 2, 3, 4, 5)
+	checkList(t, other)
+	if v, found := l.Remove(moved); !found || v != 3 {
+		t.Error("Expected the spliced nodes to belong to the list they were moved to")
+	}
+
+	l.SpliceBack(NewList(6, 7))
+	l.SpliceBack(other)
+	checkList(t, l, 1, 2, 4, 5, 6, 7)
+
+	var empty List[int]
+	empty.SpliceBack(NewList(8))
+	checkList(t, &empty, 8)
+}
+
+func TestList_Rotate(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{0, []int{1, 2, 3, 4, 5}},
+		{1, []int{2, 3, 4, 5, 1}},
+		{2, []int{3, 4, 5, 1, 2}},
+		{4, []int{5, 1, 2, 3, 4}},
+		{5, []int{1, 2, 3, 4, 5}},
+		{12, []int{3, 4, 5, 1, 2}},
+		{-1, []int{5, 1, 2, 3, 4}},
+		{-7, []int{4, 5, 1, 2, 3}},
+	}
+	for _, tt := range tests {
+		l := NewList(1, 2, 3, 4, 5)
+		l.Rotate(tt.n)
+		checkList(t, l, tt.want...)
+	}
+}
+
+func TestList_Iterators(t *testing.T) {
+	l := NewList('a', 'b', 'c', 'd')
+	for n := range l.Nodes() {
+		if n.Value == 'b' || n.Value == 'c' {
+			l.Remove(n)
+		} else {
+			l.InsertAfter(n.Value-'a'+'A', n)
+		}
+	}
+	checkList(t, l, 'a', 'A', 'd', 'D')
+	if got := l.String(); got != "aAdD" {
+		t.Errorf("String() = %q, want the runes as text", got)
+	}
+	if got := NewList[rune]().String(); got != "" {
+		t.Errorf("String() of an empty list = %q", got)
+	}
+	if got := NewList(1, 2).String(); got != "[1 2]" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := l.Back().NextCircular(); got != l.Front() {
+		t.Error("Expected NextCircular() to wrap round from the back to the front")
+	}
+	if got := l.Front().PrevCircular(); got != l.Back() {
+		t.Error("Expected PrevCircular() to wrap round from the front to the back")
+	}
+}
+
+// TestList_MarbleGame plays the example game from 2018 day 9, which needs a
+// circle of marbles that can be walked either way and inserted into.
+func TestList_MarbleGame(t *testing.T) {
+	tests := []struct {
+		players, lastMarble, want int
+	}{
+		{9, 25, 32},
+		{10, 1618, 8317},
+		{13, 7999, 146373},
+		{30, 5807, 37305},
+	}
+	for _, tt := range tests {
+		circle := NewList(0)
+		current := circle.Front()
+		scores := make([]int, tt.players)
+		for marble := 1; marble <= tt.lastMarble; marble++ {
+			if marble%23 != 0 {
+				current = circle.InsertAfter(marble, current.NextCircular())
+				continue
+			}
+			for range 7 {
+				current = current.PrevCircular()
+			}
+			removed := current
+			current = current.NextCircular()
+			value, _ := circle.Remove(removed)
+			scores[marble%tt.players] += marble + value
+		}
+		if got := slices.Max(scores); got != tt.want {
+			t.Errorf("%d players, last marble %d: high score %d, want %d", tt.players, tt.lastMarble, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package data
+
+import (
+	"bufio"
+	"cmp"
+	"io"
+	"iter"
+	"slices"
+)
+
+// Match is a word found in the input, from byte offset Start up to End, along
+// with the value the word was given.
+type Match[V any] struct {
+	Start, End int
+	Word       string
+	Value      V
+}
+
+// Matcher finds every occurrence of a set of words, overlapping ones included,
+// in a single pass over the input. It is an Aho-Corasick automaton: a trie of
+// the words, where each node also links to the node of the longest suffix of
+// its prefix that is in the trie, to fall back on when the next byte does not
+// continue a word.
+type Matcher[V any] struct {
+	words  []string
+	values []V
+	nodes  []matcherNode
+}
+
+type matcherNode struct {
+	next  map[byte]int
+	fail  int
+	depth int
+	// outputs are the words that end at the node, longest first
+	outputs []int
+}
+
+// NewMatcher creates a matcher for the words, each reported with its value.
+// Empty words are ignored.
+func NewMatcher[V any](words map[string]V) *Matcher[V] {
+	m := &Matcher[V]{nodes: []matcherNode{{next: make(map[byte]int)}}}
+
+	// add the words in order, so that the matcher is the same on every run
+	sorted := make([]string, 0, len(words))
+	for word := range words {
+		if word != "" {
+			sorted = append(sorted, word)
+		}
+	}
+	slices.Sort(sorted)
+
+	for _, word := range sorted {
+		node := 0
+		for i := 0; i < len(word); i++ {
+			child, found := m.nodes[node].next[word[i]]
+			if !found {
+				child = len(m.nodes)
+				m.nodes = append(m.nodes, matcherNode{next: make(map[byte]int), depth: i + 1})
+				m.nodes[node].next[word[i]] = child
+			}
+			node = child
+		}
+		m.nodes[node].outputs = append(m.nodes[node].outputs, len(m.words))
+		m.words = append(m.words, word)
+		m.values = append(m.values, words[word])
+	}
+
+	// link the nodes breadth first, so that the shorter node a node falls back
+	// on is always linked before it
+	queue := NewDeque[int]()
+	queue.PushBack(0)
+	for {
+		node, found := queue.PopFront()
+		if !found {
+			break
+		}
+		for b, child := range m.nodes[node].next {
+			if node != 0 {
+				m.nodes[child].fail = m.step(m.nodes[node].fail, b)
+			}
+			fail := m.nodes[child].fail
+			m.nodes[child].outputs = append(m.nodes[child].outputs, m.nodes[fail].outputs...)
+			queue.PushBack(child)
+		}
+	}
+	return m
+}
+
+// step follows the byte on from the node, falling back along the links until
+// a node continues with it.
+func (m *Matcher[V]) step(node int, b byte) int {
+	for {
+		if child, found := m.nodes[node].next[b]; found {
+			return child
+		}
+		if node == 0 {
+			return 0
+		}
+		node = m.nodes[node].fail
+	}
+}
+
+// MatcherState is how far a matcher has got through its input. It is a value,
+// so a state can be kept and carried on from later, such as between chunks of
+// a stream.
+type MatcherState[V any] struct {
+	m    *Matcher[V]
+	node int
+}
+
+// Start returns the state before any input has been read.
+func (m *Matcher[V]) Start() MatcherState[V] {
+	return MatcherState[V]{m: m}
+}
+
+// Next returns the state after reading the byte.
+func (s MatcherState[V]) Next(b byte) MatcherState[V] {
+	return MatcherState[V]{m: s.m, node: s.m.step(s.node, b)}
+}
+
+// PrefixLen returns the length of the longest end of the input read so far that
+// is the start of a word. Those bytes could still become a match, so input cut
+// into chunks should not be split within them.
+func (s MatcherState[V]) PrefixLen() int {
+	return s.m.nodes[s.node].depth
+}
+
+// Matches returns the words that end with the last byte read, longest first,
+// given the offset of the end of the input read so far.
+func (s MatcherState[V]) Matches(end int) []Match[V] {
+	outputs := s.m.nodes[s.node].outputs
+	if len(outputs) == 0 {
+		return nil
+	}
+	matches := make([]Match[V], len(outputs))
+	for i, w := range outputs {
+		word := s.m.words[w]
+		matches[i] = Match[V]{Start: end - len(word), End: end, Word: word, Value: s.m.values[w]}
+	}
+	return matches
+}
+
+// All iterates over every match in the string, in the order they end, with the
+// longest first where several end together.
+func (m *Matcher[V]) All(s string) iter.Seq[Match[V]] {
+	return func(yield func(Match[V]) bool) {
+		state := m.Start()
+		for i := 0; i < len(s); i++ {
+			state = state.Next(s[i])
+			for _, match := range state.Matches(i + 1) {
+				if !yield(match) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// FindAll returns every match in the string, ordered by where they start, with
+// the longest first where several start together.
+func (m *Matcher[V]) FindAll(s string) []Match[V] {
+	matches := slices.Collect(m.All(s))
+	slices.SortStableFunc(matches, func(a, b Match[V]) int {
+		return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(b.End, a.End))
+	})
+	return matches
+}
+
+// Scan iterates over every match in the stream, in the order they end, reading
+// it a byte at a time. It stops after yielding any error reading the stream.
+func (m *Matcher[V]) Scan(r io.Reader) iter.Seq2[Match[V], error] {
+	return func(yield func(Match[V], error) bool) {
+		br := bufio.NewReader(r)
+		state := m.Start()
+		for offset := 1; ; offset++ {
+			b, err := br.ReadByte()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(Match[V]{}, err)
+				return
+			}
+			state = state.Next(b)
+			for _, match := range state.Matches(offset) {
+				if !yield(match, nil) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package data
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMatcher_FindAll(t *testing.T) {
+	m := NewMatcher(map[string]int{"he": 1, "she": 2, "his": 3, "hers": 4})
+
+	got := m.FindAll("ushers")
+	want := []Match[int]{
+		{Start: 1, End: 4, Word: "she", Value: 2},
+		{Start: 2, End: 6, Word: "hers", Value: 4},
+		{Start: 2, End: 4, Word: "he", Value: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll() = %v, want %v", got, want)
+	}
+}
+
+func TestMatcher_Overlapping(t *testing.T) {
+	m := NewMatcher(map[string]int{"one": 1, "two": 2, "eight": 8, "nine": 9})
+
+	tests := []struct {
+		input string
+		want  []int
+	}{
+		{"eightwo", []int{8, 2}},
+		{"oneight", []int{1, 8}},
+		{"twoneighthree", []int{2, 1, 8}},
+		{"nineight", []int{9, 8}},
+		{"nothing", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			var got []int
+			for _, match := range m.FindAll(tt.input) {
+				got = append(got, match.Value)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("FindAll() values = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcher_PrefixLen(t *testing.T) {
+	m := NewMatcher(map[string]bool{"mul(": true, "do()": true, "don't()": false})
+
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"", 0},
+		{"xmu", 2},
+		{"mul(", 4},
+		{"mul(1", 0},
+		{"dodon'", 4},
+		{"don't()", 7},
+		{"abc", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			state := m.Start()
+			for i := 0; i < len(tt.input); i++ {
+				state = state.Next(tt.input[i])
+			}
+			if got := state.PrefixLen(); got != tt.want {
+				t.Errorf("PrefixLen() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMatcher_MatchesBruteForce compares the matches in random strings with
+// those found by checking for every word at every offset.
+func TestMatcher_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	random := func(n int) string {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = "abc"[rng.Intn(3)]
+		}
+		return string(b)
+	}
+
+	for range 100 {
+		words := make(map[string]int)
+		for range 5 {
+			words[random(1+rng.Intn(4))] = rng.Intn(100)
+		}
+		m := NewMatcher(words)
+		input := random(50)
+
+		var want []Match[int]
+		for start := range len(input) {
+			for end := len(input); end > start; end-- {
+				if value, found := words[input[start:end]]; found {
+					want = append(want, Match[int]{Start: start, End: end, Word: input[start:end], Value: value})
+				}
+			}
+		}
+		if got := m.FindAll(input); !reflect.DeepEqual(got, want) {
+			t.Fatalf("FindAll(%q) with %v = %v, want %v", input, words, got, want)
+		}
+
+		var scanned []Match[int]
+		for match, err := range m.Scan(strings.NewReader(input)) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			scanned = append(scanned, match)
+		}
+		if all := slices.Collect(m.All(input)); !reflect.DeepEqual(scanned, all) {
+			t.Fatalf("Scan() = %v, want the same as All() = %v", scanned, all)
+		}
+	}
+}
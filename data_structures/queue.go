@@ -1,47 +1,68 @@
 package data
 
-type Queue struct {
-	queue []interface{}
+import "iter"
+
+// Queue is a first-in, first-out collection of items of type T. Code written
+// for the untyped queue can use Queue[any] until its items are given a type.
+// Popping does not release the queue's memory, so prefer Deque for queues that
+// see many items, such as a breadth-first search.
+type Queue[T any] struct {
+	queue []T
 }
 
-func NewQueue() *Queue {
-	stack := make([]interface{}, 0, 100)
-	return &Queue{queue: stack}
+func NewQueue[T any]() *Queue[T] {
+	stack := make([]T, 0, 100)
+	return &Queue[T]{queue: stack}
 }
 
-func (s *Queue) Push(item interface{}) {
+func (s *Queue[T]) Push(item T) {
 	s.queue = append(s.queue, item)
 }
 
-func (s *Queue) Pop() (value interface{}, found bool) {
+func (s *Queue[T]) Pop() (value T, found bool) {
 	item, found := s.Peek()
 	if found {
+		var zero T
+		s.queue[0] = zero // so that the queue does not keep the item alive
 		s.queue = s.queue[1:]
 	}
 	return item, found
 }
 
-func (s *Queue) Peek() (value interface{}, found bool) {
+func (s *Queue[T]) Peek() (value T, found bool) {
 	length := len(s.queue)
 	if length == 0 {
-		return nil, false
+		return value, false
 	}
 
 	item := s.queue[0]
 	return item, true
 }
 
-func (s *Queue) Copy() *Queue {
-	newCopy := make([]interface{}, len(s.queue))
+func (s *Queue[T]) Copy() *Queue[T] {
+	newCopy := make([]T, len(s.queue))
 	copy(newCopy, s.queue)
-	result := &Queue{queue: newCopy}
+	result := &Queue[T]{queue: newCopy}
 	return result
 }
 
-func (s *Queue) Size() int {
+func (s *Queue[T]) Size() int {
 	return len(s.queue)
 }
 
-func (s *Queue) Items() []interface{} {
+// Items returns the items from the front of the queue to the back.
+func (s *Queue[T]) Items() []T {
 	return s.queue
 }
+
+// All iterates over the items from the front of the queue to the back, which
+// is the order they would be popped in, without removing them.
+func (s *Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range s.queue {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package data
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestQueue_Push(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []any
+	}{
+		{"One item", []any{10}},
+		{"Three items in order", []any{10, "abc", 30}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sut := NewQueue[any]()
+			for i, item := range tt.items {
+				sut.Push(item)
+				if sut.queue[i] != item {
+					t.Errorf("Expected item '%v' to be in position %d", item, i)
+				}
+			}
+		})
+	}
+}
+
+func TestQueue_Pop(t *testing.T) {
+	tests := []struct {
+		name      string
+		queue     []any
+		wantItem  any
+		wantFound bool
+	}{
+		{"Pop only item", []any{"abc"}, "abc", true},
+		{"Pop first item", []any{"abc", 20}, "abc", true},
+		{"Already empty", []any{}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Queue[any]{queue: tt.queue}
+			if got, success := q.Pop(); !reflect.DeepEqual(got, tt.wantItem) || tt.wantFound != success {
+				t.Errorf("Queue.Pop() = (%v, %v), want (%v, %v)", got, success, tt.wantItem, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestQueue_Copy(t *testing.T) {
+	q := NewQueue[int]()
+	q.Push(1)
+	q.Push(2)
+	c := q.Copy()
+	c.Push(3)
+	q.Pop()
+
+	if !reflect.DeepEqual(q.Items(), []int{2}) {
+		t.Errorf("Queue.Items() = %v, want [2]", q.Items())
+	}
+	if !reflect.DeepEqual(c.Items(), []int{1, 2, 3}) {
+		t.Errorf("Copy().Items() = %v, want [1 2 3]", c.Items())
+	}
+}
+
+func TestQueue_All(t *testing.T) {
+	q := NewQueue[string]()
+	for _, item := range []string{"a", "b", "c"} {
+		q.Push(item)
+	}
+
+	if got := slices.Collect(q.All()); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Queue.All() = %v, want [a b c]", got)
+	}
+	if got, _ := q.Peek(); q.Size() != 3 || got != "a" {
+		t.Errorf("Expected All to leave the items in the queue, size is %d", q.Size())
+	}
+}
@@ -0,0 +1,107 @@
+package data
+
+import (
+	"iter"
+	"math/bits"
+	"strings"
+)
+
+// RuneSet is a set of the ASCII letters a-z and A-Z, held in a single word. It
+// is a value, so the set operations leave their operands unchanged, and it can
+// be compared with == and used as a map key.
+type RuneSet uint64
+
+// NewRuneSet creates a set of the letters in the string, ignoring any other
+// characters.
+func NewRuneSet(s string) RuneSet {
+	var set RuneSet
+	for _, r := range s {
+		set.Add(r)
+	}
+	return set
+}
+
+// runeBit returns the position of the letter in the set: a-z are 0-25 and A-Z
+// are 26-51.
+func runeBit(r rune) (int, bool) {
+	switch {
+	case 'a' <= r && r <= 'z':
+		return int(r - 'a'), true
+	case 'A' <= r && r <= 'Z':
+		return int(r-'A') + 26, true
+	default:
+		return 0, false
+	}
+}
+
+// Add puts the letter into the set, reporting false if it is not a letter.
+func (s *RuneSet) Add(r rune) bool {
+	bit, found := runeBit(r)
+	if found {
+		*s |= 1 << bit
+	}
+	return found
+}
+
+// Remove takes the letter out of the set.
+func (s *RuneSet) Remove(r rune) {
+	if bit, found := runeBit(r); found {
+		*s &^= 1 << bit
+	}
+}
+
+// Contains reports whether the letter is in the set.
+func (s RuneSet) Contains(r rune) bool {
+	bit, found := runeBit(r)
+	return found && s&(1<<bit) != 0
+}
+
+// Count returns the number of letters in the set.
+func (s RuneSet) Count() int {
+	return bits.OnesCount64(uint64(s))
+}
+
+// And returns a set of the letters in both sets.
+func (s RuneSet) And(other RuneSet) RuneSet {
+	return s & other
+}
+
+// Or returns a set of the letters in either set.
+func (s RuneSet) Or(other RuneSet) RuneSet {
+	return s | other
+}
+
+// Xor returns a set of the letters in one set but not both.
+func (s RuneSet) Xor(other RuneSet) RuneSet {
+	return s ^ other
+}
+
+// AndNot returns a set of the letters in this set that are not in the other.
+func (s RuneSet) AndNot(other RuneSet) RuneSet {
+	return s &^ other
+}
+
+// All iterates over the letters in the set, a-z and then A-Z.
+func (s RuneSet) All() iter.Seq[rune] {
+	return func(yield func(rune) bool) {
+		for w := uint64(s); w != 0; w &= w - 1 {
+			bit := bits.TrailingZeros64(w)
+			r := 'a' + rune(bit)
+			if bit >= 26 {
+				r = 'A' + rune(bit-26)
+			}
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// String returns the letters in the set, a-z and then A-Z.
+func (s RuneSet) String() string {
+	var b strings.Builder
+	for r := range s.All() {
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -1,25 +1,30 @@
 package data
 
-type Stack struct {
-	stack []interface{}
+import "iter"
+
+// Stack is a last-in, first-out collection of items of type T. Code written for
+// the untyped stack can use Stack[any] until its items are given a type.
+type Stack[T any] struct {
+	stack []T
 }
 
-func NewStack() *Stack {
-	stack := make([]interface{}, 0, 100)
-	return &Stack{stack: stack}
+func NewStack[T any]() *Stack[T] {
+	stack := make([]T, 0, 100)
+	return &Stack[T]{stack: stack}
 }
 
-func NewStackFromItems(items []interface{}) *Stack {
-	stack := NewStack()
+// NewStackFromItems returns a stack holding the items, with the last item on top.
+func NewStackFromItems[T any](items []T) *Stack[T] {
+	stack := NewStack[T]()
 	stack.stack = items
 	return stack
 }
 
-func (s *Stack) Push(item interface{}) {
+func (s *Stack[T]) Push(item T) {
 	s.stack = append(s.stack, item)
 }
 
-func (s *Stack) Pop() (value interface{}, found bool) {
+func (s *Stack[T]) Pop() (value T, found bool) {
 	item, found := s.Peek()
 	if found {
 		s.stack = s.stack[:len(s.stack)-1]
@@ -27,27 +32,40 @@ func (s *Stack) Pop() (value interface{}, found bool) {
 	return item, found
 }
 
-func (s *Stack) Peek() (value interface{}, found bool) {
+func (s *Stack[T]) Peek() (value T, found bool) {
 	length := len(s.stack)
 	if length == 0 {
-		return nil, false
+		return value, false
 	}
 
 	item := s.stack[length-1]
 	return item, true
 }
 
-func (s *Stack) Copy() *Stack {
-	newCopy := make([]interface{}, len(s.stack))
+func (s *Stack[T]) Copy() *Stack[T] {
+	newCopy := make([]T, len(s.stack))
 	copy(newCopy, s.stack)
-	result := &Stack{stack: newCopy}
+	result := &Stack[T]{stack: newCopy}
 	return result
 }
 
-func (s *Stack) Size() int {
+func (s *Stack[T]) Size() int {
 	return len(s.stack)
 }
 
-func (s *Stack) Items() []interface{} {
+// Items returns the items from the bottom of the stack to the top.
+func (s *Stack[T]) Items() []T {
 	return s.stack
 }
+
+// All iterates over the items from the top of the stack to the bottom, which
+// is the order they would be popped in, without removing them.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.stack) - 1; i >= 0; i-- {
+			if !yield(s.stack[i]) {
+				return
+			}
+		}
+	}
+}
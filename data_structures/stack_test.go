@@ -2,6 +2,7 @@ package data
 
 import (
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -19,7 +20,7 @@ func TestPush(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			sut := NewStack()
+			sut := NewStack[any]()
 			for i, item := range tt.args.items {
 				sut.Push(item)
 				if sut.stack[i] != item {
@@ -59,7 +60,7 @@ func TestStack_Pop(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := &Stack{
+			s := &Stack[any]{
 				stack: tt.fields.stack,
 			}
 			if got, success := s.Pop(); !reflect.DeepEqual(got, tt.wantItem) || tt.wantFound != success {
@@ -68,3 +69,52 @@ func TestStack_Pop(t *testing.T) {
 		})
 	}
 }
+
+func TestStack_Peek(t *testing.T) {
+	s := NewStackFromItems([]rune{'a', 'b'})
+	if got, found := s.Peek(); got != 'b' || !found {
+		t.Errorf("Stack.Peek() = (%q, %v), want ('b', true)", got, found)
+	}
+	if s.Size() != 2 {
+		t.Errorf("Expected Peek to leave both items on the stack, size is %d", s.Size())
+	}
+
+	empty := NewStack[rune]()
+	if got, found := empty.Peek(); got != 0 || found {
+		t.Errorf("Stack.Peek() = (%q, %v), want (0, false)", got, found)
+	}
+}
+
+func TestStack_Copy(t *testing.T) {
+	s := NewStackFromItems([]int{1, 2})
+	c := s.Copy()
+	c.Push(3)
+	s.Pop()
+
+	if !reflect.DeepEqual(s.Items(), []int{1}) {
+		t.Errorf("Stack.Items() = %v, want [1]", s.Items())
+	}
+	if !reflect.DeepEqual(c.Items(), []int{1, 2, 3}) {
+		t.Errorf("Copy().Items() = %v, want [1 2 3]", c.Items())
+	}
+}
+
+func TestStack_All(t *testing.T) {
+	s := NewStack[string]()
+	for _, item := range []string{"a", "b", "c"} {
+		s.Push(item)
+	}
+
+	if got := slices.Collect(s.All()); !reflect.DeepEqual(got, []string{"c", "b", "a"}) {
+		t.Errorf("Stack.All() = %v, want [c b a]", got)
+	}
+	for item := range s.All() {
+		if item != "c" {
+			t.Errorf("Expected to stop after the top item, got %q", item)
+		}
+		break
+	}
+	if s.Size() != 3 {
+		t.Errorf("Expected All to leave the items on the stack, size is %d", s.Size())
+	}
+}
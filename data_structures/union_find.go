@@ -0,0 +1,98 @@
+package data
+
+// UnionFind is a disjoint-set forest, that groups items into components and
+// answers whether two items are in the same component. It uses union by rank
+// and path compression, so each operation takes close to constant time.
+type UnionFind[T comparable] struct {
+	parent     map[T]T
+	rank       map[T]int
+	size       map[T]int
+	items      []T
+	components int
+}
+
+func NewUnionFind[T comparable]() *UnionFind[T] {
+	return &UnionFind[T]{parent: make(map[T]T), rank: make(map[T]int), size: make(map[T]int)}
+}
+
+// Add puts the item in a component of its own, unless it has already been added.
+func (u *UnionFind[T]) Add(item T) {
+	if _, found := u.parent[item]; found {
+		return
+	}
+	u.parent[item] = item
+	u.size[item] = 1
+	u.items = append(u.items, item)
+	u.components++
+}
+
+// Find returns the representative item of the item's component, adding the
+// item first if needed.
+func (u *UnionFind[T]) Find(item T) T {
+	u.Add(item)
+	root := item
+	for u.parent[root] != root {
+		root = u.parent[root]
+	}
+	// point every item on the way directly at the root
+	for item != root {
+		next := u.parent[item]
+		u.parent[item] = root
+		item = next
+	}
+	return root
+}
+
+// Union merges the components of the two items, reporting whether they were
+// in different components.
+func (u *UnionFind[T]) Union(a, b T) bool {
+	rootA, rootB := u.Find(a), u.Find(b)
+	if rootA == rootB {
+		return false
+	}
+	if u.rank[rootA] < u.rank[rootB] {
+		rootA, rootB = rootB, rootA
+	}
+	u.parent[rootB] = rootA
+	u.size[rootA] += u.size[rootB]
+	delete(u.size, rootB)
+	if u.rank[rootA] == u.rank[rootB] {
+		u.rank[rootA]++
+	}
+	delete(u.rank, rootB)
+	u.components--
+	return true
+}
+
+// Connected reports whether the two items are in the same component.
+func (u *UnionFind[T]) Connected(a, b T) bool {
+	return u.Find(a) == u.Find(b)
+}
+
+// Size returns the number of items in the item's component.
+func (u *UnionFind[T]) Size(item T) int {
+	return u.size[u.Find(item)]
+}
+
+// Count returns the number of components.
+func (u *UnionFind[T]) Count() int {
+	return u.components
+}
+
+// Components returns the items grouped by component. The components, and the
+// items within them, are in the order the items were added.
+func (u *UnionFind[T]) Components() [][]T {
+	index := make(map[T]int, u.components)
+	components := make([][]T, 0, u.components)
+	for _, item := range u.items {
+		root := u.Find(item)
+		i, found := index[root]
+		if !found {
+			i = len(components)
+			index[root] = i
+			components = append(components, make([]T, 0, u.size[root]))
+		}
+		components[i] = append(components[i], item)
+	}
+	return components
+}
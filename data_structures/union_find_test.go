@@ -0,0 +1,77 @@
+package data
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnionFind(t *testing.T) {
+	u := NewUnionFind[string]()
+	for _, item := range []string{"a", "b", "c", "d", "e"} {
+		u.Add(item)
+	}
+	if u.Count() != 5 {
+		t.Fatalf("Count() = %d, want 5 before any unions", u.Count())
+	}
+
+	tests := []struct {
+		a, b       string
+		wantMerged bool
+		wantCount  int
+	}{
+		{"a", "b", true, 4},
+		{"c", "d", true, 3},
+		{"b", "a", false, 3},
+		{"b", "d", true, 2},
+		{"a", "c", false, 2},
+	}
+	for _, tt := range tests {
+		if merged := u.Union(tt.a, tt.b); merged != tt.wantMerged || u.Count() != tt.wantCount {
+			t.Errorf("Union(%s, %s) = %v with %d components, want %v with %d",
+				tt.a, tt.b, merged, u.Count(), tt.wantMerged, tt.wantCount)
+		}
+	}
+
+	if !u.Connected("a", "d") || u.Connected("a", "e") {
+		t.Error("Expected a to be connected to d, and not to e")
+	}
+	if u.Size("c") != 4 || u.Size("e") != 1 {
+		t.Errorf("Size(c), Size(e) = %d, %d, want 4, 1", u.Size("c"), u.Size("e"))
+	}
+	if got := u.Components(); !reflect.DeepEqual(got, [][]string{{"a", "b", "c", "d"}, {"e"}}) {
+		t.Errorf("Components() = %v, want [[a b c d] [e]]", got)
+	}
+}
+
+func TestUnionFind_FindAddsItems(t *testing.T) {
+	u := NewUnionFind[int]()
+	if root := u.Find(7); root != 7 || u.Count() != 1 {
+		t.Errorf("Find(7) = %d with %d components, want 7 with 1", root, u.Count())
+	}
+	u.Union(1, 2)
+	if u.Count() != 2 || u.Find(1) != u.Find(2) {
+		t.Errorf("Expected Union to add both items to one component, have %d components", u.Count())
+	}
+}
+
+func TestUnionFind_LongChain(t *testing.T) {
+	const n = 100_000
+	u := NewUnionFind[int]()
+	for i := 1; i < n; i++ {
+		u.Union(i-1, i)
+	}
+	if u.Count() != 1 || u.Size(0) != n || !u.Connected(0, n-1) {
+		t.Errorf("Expected one component of %d, have %d components, size %d", n, u.Count(), u.Size(0))
+	}
+}
+
+func BenchmarkUnionFind(b *testing.B) {
+	const n = 1_000_000
+	for i := 0; i < b.N; i++ {
+		u := NewUnionFind[int]()
+		for item := 0; item < n; item++ {
+			u.Union(item, item/2)
+		}
+		u.Connected(0, n-1)
+	}
+}
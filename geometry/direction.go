@@ -0,0 +1,74 @@
+package geometry
+
+// Direction is one of the eight points of the compass, numbered clockwise
+// from North.
+type Direction int
+
+const (
+	North Direction = iota
+	NorthEast
+	East
+	SouthEast
+	South
+	SouthWest
+	West
+	NorthWest
+)
+
+var (
+	// Cardinals are the four directions along the axes, clockwise from North.
+	Cardinals = []Direction{North, East, South, West}
+	// Ordinals are the four diagonal directions, clockwise from NorthEast.
+	Ordinals = []Direction{NorthEast, SouthEast, SouthWest, NorthWest}
+	// Directions are all eight directions, clockwise from North.
+	Directions = []Direction{North, NorthEast, East, SouthEast, South, SouthWest, West, NorthWest}
+)
+
+var (
+	steps = [...]struct{ x, y int }{
+		North:     {0, -1},
+		NorthEast: {1, -1},
+		East:      {1, 0},
+		SouthEast: {1, 1},
+		South:     {0, 1},
+		SouthWest: {-1, 1},
+		West:      {-1, 0},
+		NorthWest: {-1, -1},
+	}
+	names = [...]string{"North", "NorthEast", "East", "SouthEast", "South", "SouthWest", "West", "NorthWest"}
+)
+
+// Step returns the vector of a single step in the direction.
+func Step[T Integer](d Direction) Vector[T] {
+	s := steps[d.normalize()]
+	return Vector[T]{X: T(s.x), Y: T(s.y)}
+}
+
+// normalize brings the direction back into the range North to NorthWest.
+func (d Direction) normalize() Direction {
+	return (d%8 + 8) % 8
+}
+
+// TurnLeft returns the direction a quarter turn counter-clockwise.
+func (d Direction) TurnLeft() Direction {
+	return (d - 2).normalize()
+}
+
+// TurnRight returns the direction a quarter turn clockwise.
+func (d Direction) TurnRight() Direction {
+	return (d + 2).normalize()
+}
+
+// Reverse returns the opposite direction.
+func (d Direction) Reverse() Direction {
+	return (d + 4).normalize()
+}
+
+// IsCardinal reports whether the direction is along one of the axes.
+func (d Direction) IsCardinal() bool {
+	return d.normalize()%2 == 0
+}
+
+func (d Direction) String() string {
+	return names[d.normalize()]
+}
@@ -0,0 +1,101 @@
+// Package geometry provides points, vectors and compass directions on an
+// integer plane, for puzzles that move around a map.
+//
+// Y increases down the plane, as rows do when a map is read from the input, so
+// North is towards smaller Y.
+package geometry
+
+import "fmt"
+
+// Integer is any integer type that coordinates can be held in. It is signed,
+// so that vectors can point either way along an axis.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Point is a location on the plane.
+type Point[T Integer] struct {
+	X, Y T
+}
+
+// Vector is the offset from one point to another.
+type Vector[T Integer] struct {
+	X, Y T
+}
+
+// Add returns the point moved by the vector.
+func (p Point[T]) Add(v Vector[T]) Point[T] {
+	return Point[T]{X: p.X + v.X, Y: p.Y + v.Y}
+}
+
+// Sub returns the vector from the other point to this one.
+func (p Point[T]) Sub(q Point[T]) Vector[T] {
+	return Vector[T]{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+// Move returns the point one step away in the direction.
+func (p Point[T]) Move(d Direction) Point[T] {
+	return p.Add(Step[T](d))
+}
+
+// Manhattan returns the distance between the points moving only along the
+// axes.
+func (p Point[T]) Manhattan(q Point[T]) T {
+	return p.Sub(q).Manhattan()
+}
+
+// Chebyshev returns the distance between the points when moving diagonally
+// costs the same as moving along an axis, like a king on a chess board.
+func (p Point[T]) Chebyshev(q Point[T]) T {
+	return p.Sub(q).Chebyshev()
+}
+
+func (p Point[T]) String() string {
+	return fmt.Sprintf("(%v,%v)", p.X, p.Y)
+}
+
+// Add returns the sum of the vectors.
+func (v Vector[T]) Add(w Vector[T]) Vector[T] {
+	return Vector[T]{X: v.X + w.X, Y: v.Y + w.Y}
+}
+
+// Scale returns the vector multiplied by n.
+func (v Vector[T]) Scale(n T) Vector[T] {
+	return Vector[T]{X: v.X * n, Y: v.Y * n}
+}
+
+// Reverse returns the vector pointing the opposite way.
+func (v Vector[T]) Reverse() Vector[T] {
+	return Vector[T]{X: -v.X, Y: -v.Y}
+}
+
+// TurnLeft returns the vector turned a quarter turn counter-clockwise.
+func (v Vector[T]) TurnLeft() Vector[T] {
+	return Vector[T]{X: v.Y, Y: -v.X}
+}
+
+// TurnRight returns the vector turned a quarter turn clockwise.
+func (v Vector[T]) TurnRight() Vector[T] {
+	return Vector[T]{X: -v.Y, Y: v.X}
+}
+
+// Manhattan returns the sum of the lengths of the vector along each axis.
+func (v Vector[T]) Manhattan() T {
+	return abs(v.X) + abs(v.Y)
+}
+
+// Chebyshev returns the longer of the lengths of the vector along each axis.
+func (v Vector[T]) Chebyshev() T {
+	return max(abs(v.X), abs(v.Y))
+}
+
+func (v Vector[T]) String() string {
+	return fmt.Sprintf("<%v,%v>", v.X, v.Y)
+}
+
+func abs[T Integer](n T) T {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,107 @@
+package geometry
+
+import "testing"
+
+func TestPoint_Distances(t *testing.T) {
+	tests := []struct {
+		name          string
+		p, q          Point[int]
+		wantManhattan int
+		wantChebyshev int
+	}{
+		{"same point", Point[int]{3, 4}, Point[int]{3, 4}, 0, 0},
+		{"along an axis", Point[int]{0, 0}, Point[int]{0, -5}, 5, 5},
+		{"diagonal", Point[int]{1, 1}, Point[int]{-2, 4}, 6, 3},
+		{"either side of the origin", Point[int]{-3, 2}, Point[int]{4, -1}, 10, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Manhattan(tt.q); got != tt.wantManhattan {
+				t.Errorf("Manhattan() = %d, want %d", got, tt.wantManhattan)
+			}
+			if got := tt.q.Manhattan(tt.p); got != tt.wantManhattan {
+				t.Errorf("Manhattan() reversed = %d, want %d", got, tt.wantManhattan)
+			}
+			if got := tt.p.Chebyshev(tt.q); got != tt.wantChebyshev {
+				t.Errorf("Chebyshev() = %d, want %d", got, tt.wantChebyshev)
+			}
+		})
+	}
+}
+
+func TestPoint_Int8(t *testing.T) {
+	p := Point[int8]{-2, 3}.Move(SouthWest).Add(Vector[int8]{1, 1}.Scale(2))
+	if want := (Point[int8]{-1, 6}); p != want {
+		t.Errorf("Got %v, want %v", p, want)
+	}
+	if d := p.Manhattan(Point[int8]{}); d != 7 {
+		t.Errorf("Manhattan() = %d, want 7", d)
+	}
+}
+
+func TestVector_Turns(t *testing.T) {
+	v := Vector[int]{2, -1}
+	if got, want := v.TurnRight(), (Vector[int]{1, 2}); got != want {
+		t.Errorf("TurnRight() = %v, want %v", got, want)
+	}
+	if got, want := v.TurnLeft(), (Vector[int]{-1, -2}); got != want {
+		t.Errorf("TurnLeft() = %v, want %v", got, want)
+	}
+	if got := v.TurnLeft().TurnLeft(); got != v.Reverse() {
+		t.Errorf("Two left turns = %v, want %v", got, v.Reverse())
+	}
+	if got := v.TurnRight().TurnLeft(); got != v {
+		t.Errorf("A right turn then a left turn = %v, want %v", got, v)
+	}
+}
+
+func TestDirection_Turns(t *testing.T) {
+	tests := []struct {
+		d                                Direction
+		wantLeft, wantRight, wantReverse Direction
+	}{
+		{North, West, East, South},
+		{East, North, South, West},
+		{South, East, West, North},
+		{West, South, North, East},
+		{NorthEast, NorthWest, SouthEast, SouthWest},
+		{NorthWest, SouthWest, NorthEast, SouthEast},
+	}
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			if got := tt.d.TurnLeft(); got != tt.wantLeft {
+				t.Errorf("TurnLeft() = %v, want %v", got, tt.wantLeft)
+			}
+			if got := tt.d.TurnRight(); got != tt.wantRight {
+				t.Errorf("TurnRight() = %v, want %v", got, tt.wantRight)
+			}
+			if got := tt.d.Reverse(); got != tt.wantReverse {
+				t.Errorf("Reverse() = %v, want %v", got, tt.wantReverse)
+			}
+		})
+	}
+}
+
+func TestDirection_StepsMatchVectorTurns(t *testing.T) {
+	for _, d := range Directions {
+		step := Step[int](d)
+		if got := Step[int](d.TurnRight()); got != step.TurnRight() {
+			t.Errorf("Step(%v.TurnRight()) = %v, want %v", d, got, step.TurnRight())
+		}
+		if got := Step[int](d.TurnLeft()); got != step.TurnLeft() {
+			t.Errorf("Step(%v.TurnLeft()) = %v, want %v", d, got, step.TurnLeft())
+		}
+		if got := Step[int](d.Reverse()); got != step.Reverse() {
+			t.Errorf("Step(%v.Reverse()) = %v, want %v", d, got, step.Reverse())
+		}
+		if got, want := step.Chebyshev(), 1; got != want {
+			t.Errorf("Step(%v) is %d long, want %d", d, got, want)
+		}
+		if d.IsCardinal() != (step.Manhattan() == 1) {
+			t.Errorf("%v.IsCardinal() = %t for step %v", d, d.IsCardinal(), step)
+		}
+	}
+	if got, want := Step[int](North), (Vector[int]{0, -1}); got != want {
+		t.Errorf("Step(North) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,324 @@
+// Package grid provides a dense two dimensional grid, for the many puzzles
+// whose input is a map drawn in characters.
+package grid
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	"github.com/neilfenwick/advent-of-code/geometry"
+)
+
+// ErrRagged is returned when the rows of a grid are not all the same length.
+var ErrRagged = errors.New("grid: rows are not all the same length")
+
+// Point is the location of a cell, by column X and row Y, with Y increasing
+// down the grid as the input is read.
+type Point = geometry.Point[int]
+
+// Vector is the offset from one cell to another.
+type Vector = geometry.Vector[int]
+
+var (
+	// Orthogonal are the offsets to the four neighbours of a cell: up, right,
+	// down and left.
+	Orthogonal = steps(geometry.Cardinals)
+	// Surrounding are the offsets to all eight neighbours of a cell, clockwise
+	// from up.
+	Surrounding = steps(geometry.Directions)
+)
+
+func steps(directions []geometry.Direction) []Vector {
+	offsets := make([]Vector, len(directions))
+	for i, d := range directions {
+		offsets[i] = geometry.Step[int](d)
+	}
+	return offsets
+}
+
+// Grid is a rectangular grid of cells of type T, stored densely in rows.
+//
+// When Wrap is set the grid is toroidal: a point off one edge wraps round to
+// the opposite edge, as if the grid repeated in every direction.
+type Grid[T any] struct {
+	cells         []T
+	width, height int
+	Wrap          bool
+}
+
+// New returns a grid of the given size, with every cell set to the zero value.
+func New[T any](width, height int) *Grid[T] {
+	return &Grid[T]{cells: make([]T, width*height), width: width, height: height}
+}
+
+// FromRows returns a grid holding the rows, which must all be the same length.
+func FromRows[T any](rows [][]T) (*Grid[T], error) {
+	g := &Grid[T]{height: len(rows)}
+	if len(rows) > 0 {
+		g.width = len(rows[0])
+	}
+	g.cells = make([]T, 0, g.width*g.height)
+	for y, row := range rows {
+		if len(row) != g.width {
+			return nil, fmt.Errorf("%w: row %d has %d cells, expected %d", ErrRagged, y, len(row), g.width)
+		}
+		g.cells = append(g.cells, row...)
+	}
+	return g, nil
+}
+
+// Parse reads a grid with a row per line, using the mapper to turn each
+// character into a cell. Blank lines before and after the grid are ignored.
+func Parse[T any](r io.Reader, mapper func(p Point, c rune) (T, error)) (*Grid[T], error) {
+	var rows [][]T
+	var blank bool
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		y := len(rows)
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			blank = y > 0
+			continue
+		}
+		if blank {
+			return nil, fmt.Errorf("grid: blank line before row %d", y)
+		}
+
+		row := make([]T, 0, len(line))
+		for x, c := range []rune(line) {
+			cell, err := mapper(Point{X: x, Y: y}, c)
+			if err != nil {
+				return nil, fmt.Errorf("grid: row %d column %d: %w", y, x, err)
+			}
+			row = append(row, cell)
+		}
+		rows = append(rows, row)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return FromRows(rows)
+}
+
+// ParseRunes reads a grid of the characters in the input.
+func ParseRunes(r io.Reader) (*Grid[rune], error) {
+	return Parse(r, func(_ Point, c rune) (rune, error) { return c, nil })
+}
+
+// ParseDigits reads a grid of single digit numbers.
+func ParseDigits(r io.Reader) (*Grid[int], error) {
+	return Parse(r, func(_ Point, c rune) (int, error) {
+		if c < '0' || c > '9' {
+			return 0, fmt.Errorf("%q is not a digit", c)
+		}
+		return int(c - '0'), nil
+	})
+}
+
+func (g *Grid[T]) Width() int {
+	return g.width
+}
+
+func (g *Grid[T]) Height() int {
+	return g.height
+}
+
+// InBounds reports whether the point is on the grid, ignoring Wrap.
+func (g *Grid[T]) InBounds(p Point) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+// index returns the position of the point in the cells, wrapping it round when
+// the grid wraps.
+func (g *Grid[T]) index(p Point) (int, bool) {
+	if g.Wrap && g.width > 0 && g.height > 0 {
+		p = Point{X: mod(p.X, g.width), Y: mod(p.Y, g.height)}
+	}
+	if !g.InBounds(p) {
+		return 0, false
+	}
+	return p.Y*g.width + p.X, true
+}
+
+func mod(a, n int) int {
+	return (a%n + n) % n
+}
+
+// Get returns the cell at the point, or false when the point is off the grid.
+func (g *Grid[T]) Get(p Point) (value T, found bool) {
+	i, found := g.index(p)
+	if !found {
+		return value, false
+	}
+	return g.cells[i], true
+}
+
+// Set changes the cell at the point, reporting false when the point is off the
+// grid.
+func (g *Grid[T]) Set(p Point, value T) bool {
+	i, found := g.index(p)
+	if found {
+		g.cells[i] = value
+	}
+	return found
+}
+
+// All iterates over every cell, a row at a time from the top left.
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i, cell := range g.cells {
+			if !yield(Point{X: i % g.width, Y: i / g.width}, cell) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbours iterates over the cells at each of the offsets from the point,
+// such as Orthogonal or Surrounding, that are on the grid.
+func (g *Grid[T]) Neighbours(p Point, offsets []Vector) iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for _, offset := range offsets {
+			n := p.Add(offset)
+			if i, found := g.index(n); found {
+				if g.Wrap {
+					n = Point{X: i % g.width, Y: i / g.width}
+				}
+				if !yield(n, g.cells[i]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Neighbours4 iterates over the cells above, right of, below and left of the
+// point.
+func (g *Grid[T]) Neighbours4(p Point) iter.Seq2[Point, T] {
+	return g.Neighbours(p, Orthogonal)
+}
+
+// Neighbours8 iterates over the cells surrounding the point, including the
+// diagonals.
+func (g *Grid[T]) Neighbours8(p Point) iter.Seq2[Point, T] {
+	return g.Neighbours(p, Surrounding)
+}
+
+// Row returns a copy of the cells in row y, or false when the row is off the
+// grid.
+func (g *Grid[T]) Row(y int) ([]T, bool) {
+	start, found := g.index(Point{X: 0, Y: y})
+	if !found {
+		return nil, false
+	}
+	row := make([]T, g.width)
+	copy(row, g.cells[start:start+g.width])
+	return row, true
+}
+
+// Column returns a copy of the cells in column x, or false when the column is
+// off the grid.
+func (g *Grid[T]) Column(x int) ([]T, bool) {
+	x, found := g.index(Point{X: x, Y: 0})
+	if !found {
+		return nil, false
+	}
+	column := make([]T, g.height)
+	for y := range column {
+		column[y] = g.cells[y*g.width+x]
+	}
+	return column, true
+}
+
+// FindAll returns the points of every cell that match, a row at a time.
+func (g *Grid[T]) FindAll(match func(T) bool) []Point {
+	var points []Point
+	for p, cell := range g.All() {
+		if match(cell) {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+// Find returns the point of the first cell that matches.
+func (g *Grid[T]) Find(match func(T) bool) (Point, bool) {
+	for p, cell := range g.All() {
+		if match(cell) {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
+
+// Copy returns a grid with the same cells, which can be changed independently.
+func (g *Grid[T]) Copy() *Grid[T] {
+	c := *g
+	c.cells = make([]T, len(g.cells))
+	copy(c.cells, g.cells)
+	return &c
+}
+
+// transform returns a grid of the given size, with each cell taken from the
+// point that from gives in this grid.
+func (g *Grid[T]) transform(width, height int, from func(p Point) Point) *Grid[T] {
+	t := New[T](width, height)
+	t.Wrap = g.Wrap
+	for i := range t.cells {
+		f := from(Point{X: i % width, Y: i / width})
+		t.cells[i] = g.cells[f.Y*g.width+f.X]
+	}
+	return t
+}
+
+// Transpose returns the grid reflected in its leading diagonal, so that rows
+// become columns.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.transform(g.height, g.width, func(p Point) Point { return Point{X: p.Y, Y: p.X} })
+}
+
+// RotateClockwise returns the grid turned a quarter turn clockwise.
+func (g *Grid[T]) RotateClockwise() *Grid[T] {
+	return g.transform(g.height, g.width, func(p Point) Point { return Point{X: p.Y, Y: g.height - 1 - p.X} })
+}
+
+// RotateCounterClockwise returns the grid turned a quarter turn
+// counter-clockwise.
+func (g *Grid[T]) RotateCounterClockwise() *Grid[T] {
+	return g.transform(g.height, g.width, func(p Point) Point { return Point{X: g.width - 1 - p.Y, Y: p.X} })
+}
+
+// FlipHorizontal returns the grid mirrored left to right.
+func (g *Grid[T]) FlipHorizontal() *Grid[T] {
+	return g.transform(g.width, g.height, func(p Point) Point { return Point{X: g.width - 1 - p.X, Y: p.Y} })
+}
+
+// FlipVertical returns the grid mirrored top to bottom.
+func (g *Grid[T]) FlipVertical() *Grid[T] {
+	return g.transform(g.width, g.height, func(p Point) Point { return Point{X: p.X, Y: g.height - 1 - p.Y} })
+}
+
+// String draws the grid a row per line. Runes and bytes are drawn as
+// characters, and other cells as they are formatted by fmt.Print.
+func (g *Grid[T]) String() string {
+	var b strings.Builder
+	for i, cell := range g.cells {
+		if i > 0 && i%g.width == 0 {
+			b.WriteByte('\n')
+		}
+		switch c := any(cell).(type) {
+		case rune:
+			b.WriteRune(c)
+		case byte:
+			b.WriteByte(c)
+		default:
+			fmt.Fprint(&b, c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,194 @@
+package grid
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, s string) *Grid[rune] {
+	t.Helper()
+	g, err := ParseRunes(strings.NewReader(s))
+	if err != nil {
+		t.Fatalf("ParseRunes() error = %v", err)
+	}
+	return g
+}
+
+func TestParse(t *testing.T) {
+	g := mustParse(t, "\nabc\ndef\n\n")
+	if g.Width() != 3 || g.Height() != 2 {
+		t.Fatalf("Parsed a %dx%d grid, want 3x2", g.Width(), g.Height())
+	}
+	if c, _ := g.Get(Point{X: 2, Y: 1}); c != 'f' {
+		t.Errorf("Get(2, 1) = %q, want f", c)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"ragged rows", "abc\nde\n", ErrRagged},
+		{"blank line between rows", "abc\n\ndef\n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRunes(strings.NewReader(tt.input))
+			if err == nil || (tt.wantErr != nil && !errors.Is(err, tt.wantErr)) {
+				t.Errorf("ParseRunes() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := ParseDigits(strings.NewReader("12\n3x\n")); err == nil || !strings.Contains(err.Error(), "row 1 column 1") {
+		t.Errorf("ParseDigits() error = %v, want it to name row 1 column 1", err)
+	}
+}
+
+func TestGrid_GetSet(t *testing.T) {
+	g := New[int](3, 2)
+	tests := []struct {
+		p         Point
+		wantFound bool
+	}{
+		{Point{X: 0, Y: 0}, true},
+		{Point{X: 2, Y: 1}, true},
+		{Point{X: 3, Y: 0}, false},
+		{Point{X: 0, Y: -1}, false},
+	}
+	for _, tt := range tests {
+		if found := g.Set(tt.p, 7); found != tt.wantFound {
+			t.Errorf("Set(%v) = %v, want %v", tt.p, found, tt.wantFound)
+		}
+		if v, found := g.Get(tt.p); found != tt.wantFound || (found && v != 7) {
+			t.Errorf("Get(%v) = (%d, %v), want (7, %v)", tt.p, v, found, tt.wantFound)
+		}
+	}
+}
+
+func TestGrid_Wrap(t *testing.T) {
+	g := mustParse(t, "..#\n#..\n")
+	g.Wrap = true
+
+	tests := []struct {
+		p    Point
+		want rune
+	}{
+		{Point{X: 5, Y: 0}, '#'},
+		{Point{X: -1, Y: 0}, '#'},
+		{Point{X: 3, Y: 3}, '#'},
+		{Point{X: -3, Y: -2}, '.'},
+	}
+	for _, tt := range tests {
+		if c, found := g.Get(tt.p); !found || c != tt.want {
+			t.Errorf("Get(%v) = (%q, %v), want (%q, true)", tt.p, c, found, tt.want)
+		}
+	}
+
+	var neighbours []Point
+	for p := range g.Neighbours4(Point{X: 0, Y: 0}) {
+		neighbours = append(neighbours, p)
+	}
+	if want := []Point{{X: 0, Y: 1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 2, Y: 0}}; !reflect.DeepEqual(neighbours, want) {
+		t.Errorf("Neighbours4() = %v, want %v", neighbours, want)
+	}
+}
+
+func TestGrid_Neighbours(t *testing.T) {
+	g := mustParse(t, "abc\ndef\nghi\n")
+
+	tests := []struct {
+		name string
+		got  func() []rune
+		want string
+	}{
+		{"4 in the middle", func() []rune { return collect(g.Neighbours4(Point{X: 1, Y: 1})) }, "bfhd"},
+		{"4 in the corner", func() []rune { return collect(g.Neighbours4(Point{X: 0, Y: 0})) }, "bd"},
+		{"8 in the middle", func() []rune { return collect(g.Neighbours8(Point{X: 1, Y: 1})) }, "bcfihgda"},
+		{"8 on the edge", func() []rune { return collect(g.Neighbours8(Point{X: 2, Y: 1})) }, "ciheb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.got()); got != tt.want {
+				t.Errorf("neighbours = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func collect[T any](seq func(yield func(Point, T) bool)) []T {
+	var result []T
+	for _, v := range seq {
+		result = append(result, v)
+	}
+	return result
+}
+
+func TestGrid_RowsColumnsFind(t *testing.T) {
+	g := mustParse(t, "a#c\n#e#\n")
+
+	if got, found := g.Row(1); !found || string(got) != "#e#" {
+		t.Errorf("Row(1) = (%q, %v), want #e#", string(got), found)
+	}
+	if got, found := g.Column(2); !found || string(got) != "c#" {
+		t.Errorf("Column(2) = (%q, %v), want c#", string(got), found)
+	}
+	if _, found := g.Row(2); found {
+		t.Error("Expected Row(2) to be off the grid")
+	}
+	if _, found := g.Column(-1); found {
+		t.Error("Expected Column(-1) to be off the grid")
+	}
+	g.Wrap = true
+	if got, found := g.Column(-1); !found || string(got) != "c#" {
+		t.Errorf("Column(-1) of a wrapped grid = (%q, %v), want c#", string(got), found)
+	}
+	g.Wrap = false
+
+	isWall := func(c rune) bool { return c == '#' }
+	if got := g.FindAll(isWall); !reflect.DeepEqual(got, []Point{{X: 1, Y: 0}, {X: 0, Y: 1}, {X: 2, Y: 1}}) {
+		t.Errorf("FindAll() = %v", got)
+	}
+	if p, found := g.Find(func(c rune) bool { return c == 'e' }); !found || p != (Point{X: 1, Y: 1}) {
+		t.Errorf("Find() = (%v, %v), want ({1 1}, true)", p, found)
+	}
+
+	row, _ := g.Row(0)
+	row[0] = 'z'
+	if c, _ := g.Get(Point{X: 0, Y: 0}); c != 'a' {
+		t.Error("Expected changing the row not to change the grid")
+	}
+}
+
+func TestGrid_Transforms(t *testing.T) {
+	g := mustParse(t, "abc\ndef\n")
+
+	tests := []struct {
+		name string
+		got  *Grid[rune]
+		want string
+	}{
+		{"Transpose", g.Transpose(), "ad\nbe\ncf"},
+		{"RotateClockwise", g.RotateClockwise(), "da\neb\nfc"},
+		{"RotateCounterClockwise", g.RotateCounterClockwise(), "cf\nbe\nad"},
+		{"FlipHorizontal", g.FlipHorizontal(), "cba\nfed"},
+		{"FlipVertical", g.FlipVertical(), "def\nabc"},
+		{"Four rotations", g.RotateClockwise().RotateClockwise().RotateClockwise().RotateClockwise(), "abc\ndef"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGrid_String(t *testing.T) {
+	g, _ := FromRows([][]int{{1, 2}, {3, 4}})
+	if got := g.String(); got != "12\n34" {
+		t.Errorf("String() = %q, want %q", got, "12\n34")
+	}
+}
@@ -0,0 +1,205 @@
+// Package input fetches Advent of Code puzzle inputs, and caches them in a
+// per-user directory so that each input is only downloaded once.
+//
+// Puzzle inputs are different for every user, so fetching one needs the session
+// token from the adventofcode.com cookie. The token is read from the AOC_SESSION
+// environment variable, or from the file "aoc/session" in the user's config
+// directory, e.g. ~/.config/aoc/session.
+package input
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the site that puzzle inputs are fetched from.
+	DefaultBaseURL = "https://adventofcode.com"
+
+	// DefaultInterval is the least time to wait between requests, so that the
+	// site is not flooded when fetching many inputs at once.
+	DefaultInterval = 3 * time.Second
+
+	userAgent = "github.com/neilfenwick/advent-of-code"
+)
+
+// ErrNoSession is returned when an input needs to be downloaded, but no session
+// token has been configured.
+var ErrNoSession = errors.New("input: no session token, set AOC_SESSION or save it in the aoc/session config file")
+
+// Client fetches puzzle inputs and keeps them in a cache directory.
+type Client struct {
+	// BaseURL is the site that inputs are fetched from, without a trailing slash.
+	BaseURL string
+	// Session is the value of the session cookie for the site.
+	Session string
+	// CacheDir is the directory that downloaded inputs are saved in.
+	CacheDir string
+	// Interval is the least time to wait between requests to the site.
+	Interval time.Duration
+	// HTTPClient is used to make requests, or http.DefaultClient when nil.
+	HTTPClient *http.Client
+
+	mu          sync.Mutex
+	lastRequest time.Time
+}
+
+// NewClient returns a Client configured from the environment. AOC_SESSION,
+// AOC_BASE_URL and AOC_CACHE_DIR override the session token, the site and the
+// cache directory, which otherwise default to the session config file,
+// DefaultBaseURL and "aoc" in the user's cache directory.
+func NewClient() (*Client, error) {
+	c := &Client{
+		BaseURL:  DefaultBaseURL,
+		Session:  os.Getenv("AOC_SESSION"),
+		CacheDir: os.Getenv("AOC_CACHE_DIR"),
+		Interval: DefaultInterval,
+	}
+
+	if baseURL := os.Getenv("AOC_BASE_URL"); baseURL != "" {
+		c.BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+
+	if c.CacheDir == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("input: finding cache directory: %w", err)
+		}
+		c.CacheDir = filepath.Join(dir, "aoc")
+	}
+
+	if c.Session == "" {
+		session, err := readSessionConfig()
+		if err != nil {
+			return nil, err
+		}
+		c.Session = session
+	}
+
+	return c, nil
+}
+
+// readSessionConfig reads the session token from the config file, or returns
+// an empty token when there is no config file.
+func readSessionConfig() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", nil
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "aoc", "session"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("input: reading session token: %w", err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// CachePath returns where the input for the year and day is kept in the cache.
+func (c *Client) CachePath(year, day int) string {
+	return filepath.Join(c.CacheDir, fmt.Sprint(year), fmt.Sprintf("day%d.txt", day))
+}
+
+// Cached opens the input for the year and day from the cache, without
+// downloading it. The error wraps fs.ErrNotExist when it is not in the cache.
+func (c *Client) Cached(year, day int) (io.ReadCloser, error) {
+	return os.Open(c.CachePath(year, day))
+}
+
+// Open opens the input for the year and day from the cache, downloading it
+// first if it is not in the cache yet.
+func (c *Client) Open(year, day int) (io.ReadCloser, error) {
+	f, err := c.Cached(year, day)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return f, err
+	}
+
+	if err := c.download(year, day); err != nil {
+		return nil, err
+	}
+	return c.Cached(year, day)
+}
+
+// download fetches the input for the year and day, and saves it in the cache.
+func (c *Client) download(year, day int) error {
+	if c.Session == "" {
+		return ErrNoSession
+	}
+
+	url := fmt.Sprintf("%s/%d/day/%d/input", c.BaseURL, year, day)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("input: %w", err)
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	req.Header.Set("User-Agent", userAgent)
+
+	c.wait()
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("input: fetching %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("input: fetching %s: %s", url, resp.Status)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("input: fetching %s: %w", url, err)
+	}
+	return c.save(year, day, b)
+}
+
+// wait blocks until the interval since the last request has passed.
+func (c *Client) wait() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if next := c.lastRequest.Add(c.Interval); time.Now().Before(next) {
+		time.Sleep(time.Until(next))
+	}
+	c.lastRequest = time.Now()
+}
+
+// save writes the input to a temporary file before moving it into place, so
+// that an interrupted download never leaves a partial input in the cache.
+func (c *Client) save(year, day int, b []byte) error {
+	path := c.CachePath(year, day)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("input: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return fmt.Errorf("input: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("input: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("input: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("input: %w", err)
+	}
+	return nil
+}
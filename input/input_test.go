@@ -0,0 +1,136 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestServer stands in for the puzzle site, serving an input for any year
+// and day to requests with the "test-session" cookie.
+func newTestServer(t *testing.T, requests *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+
+		var year, day int
+		if _, err := fmt.Sscanf(r.URL.Path, "/%d/day/%d/input", &year, &day); err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "test-session" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, "input for %d day %d\n", year, day)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func readAll(t *testing.T, r io.ReadCloser, err error) string {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("open error = %v", err)
+	}
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestClient_Open_DownloadsOnceThenCaches(t *testing.T) {
+	var requests int32
+	server := newTestServer(t, &requests)
+	c := &Client{BaseURL: server.URL, Session: "test-session", CacheDir: t.TempDir()}
+
+	for i := 0; i < 2; i++ {
+		r, err := c.Open(2024, 7)
+		if got := readAll(t, r, err); got != "input for 2024 day 7\n" {
+			t.Errorf("Open() = %q, want the input for 2024 day 7", got)
+		}
+	}
+	if requests := atomic.LoadInt32(&requests); requests != 1 {
+		t.Errorf("Server received %d requests, want 1", requests)
+	}
+
+	r, err := c.Cached(2024, 7)
+	if got := readAll(t, r, err); got != "input for 2024 day 7\n" {
+		t.Errorf("Cached() = %q, want the input for 2024 day 7", got)
+	}
+}
+
+func TestClient_Cached_NotDownloaded(t *testing.T) {
+	c := &Client{CacheDir: t.TempDir()}
+	if _, err := c.Cached(2024, 7); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Cached() error = %v, want fs.ErrNotExist", err)
+	}
+}
+
+func TestClient_Open_Errors(t *testing.T) {
+	var requests int32
+	server := newTestServer(t, &requests)
+
+	tests := []struct {
+		name    string
+		session string
+		wantErr error
+	}{
+		{name: "no session", session: "", wantErr: ErrNoSession},
+		{name: "wrong session", session: "someone-else"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{BaseURL: server.URL, Session: tt.session, CacheDir: t.TempDir()}
+			_, err := c.Open(2024, 7)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Open() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := c.Cached(2024, 7); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("Expected nothing to be cached after an error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestClient_Open_WaitsBetweenRequests(t *testing.T) {
+	var requests int32
+	server := newTestServer(t, &requests)
+	interval := 50 * time.Millisecond
+	c := &Client{BaseURL: server.URL, Session: "test-session", CacheDir: t.TempDir(), Interval: interval}
+
+	start := time.Now()
+	for day := 1; day <= 3; day++ {
+		r, err := c.Open(2024, day)
+		readAll(t, r, err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 2*interval {
+		t.Errorf("3 requests took %v, want at least %v", elapsed, 2*interval)
+	}
+}
+
+func TestNewClient_Environment(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv("AOC_SESSION", "test-session")
+	t.Setenv("AOC_BASE_URL", "http://localhost:8080/")
+	t.Setenv("AOC_CACHE_DIR", cacheDir)
+
+	c, err := NewClient()
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if c.Session != "test-session" || c.BaseURL != "http://localhost:8080" || c.CacheDir != cacheDir {
+		t.Errorf("NewClient() = %+v, want the settings from the environment", c)
+	}
+}
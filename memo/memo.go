@@ -0,0 +1,218 @@
+// Package memo caches the results of a function by its argument, so that
+// recursive solutions do not solve the same sub-problem more than once.
+//
+// A function of several arguments can be memoized by gathering them into a
+// comparable struct to use as the key.
+package memo
+
+import (
+	"sync"
+
+	data "github.com/neilfenwick/advent-of-code/data_structures"
+)
+
+// Stats counts how well the cache is doing.
+type Stats struct {
+	Hits, Misses, Evictions int
+	// Size is the number of results held in the cache.
+	Size int
+}
+
+// Option changes how a memoized function caches its results.
+type Option func(*config)
+
+type config struct {
+	capacity   int
+	concurrent bool
+}
+
+// WithCapacity bounds the cache to hold at most capacity results, evicting the
+// least recently used result to make room for a new one. The cache is
+// unbounded without it.
+func WithCapacity(capacity int) Option {
+	return func(c *config) {
+		c.capacity = capacity
+	}
+}
+
+// Concurrent makes the memoized function safe to call from several goroutines
+// at once. A result that is not cached yet may be worked out by more than one
+// of them, as the cache is not locked while the function runs, so that it can
+// call itself.
+func Concurrent() Option {
+	return func(c *config) {
+		c.concurrent = true
+	}
+}
+
+// Func is a function with its results cached by its argument.
+type Func[K comparable, V any] struct {
+	f     func(recurse func(K) V, key K) V
+	cache cache[K, V]
+	stats Stats
+	mu    *sync.Mutex
+}
+
+// New memoizes a function that can call itself through recurse, so that its
+// recursive calls are cached too.
+func New[K comparable, V any](f func(recurse func(K) V, key K) V, options ...Option) *Func[K, V] {
+	var c config
+	for _, option := range options {
+		option(&c)
+	}
+
+	m := &Func[K, V]{f: f}
+	if c.capacity > 0 {
+		m.cache = newLRUCache[K, V](c.capacity)
+	} else {
+		m.cache = make(mapCache[K, V])
+	}
+	if c.concurrent {
+		m.mu = &sync.Mutex{}
+	}
+	return m
+}
+
+// Of memoizes a function that does not call itself.
+func Of[K comparable, V any](f func(K) V, options ...Option) *Func[K, V] {
+	return New(func(_ func(K) V, key K) V { return f(key) }, options...)
+}
+
+// Call returns the result of the function for the key, from the cache if it
+// has been worked out before.
+func (m *Func[K, V]) Call(key K) V {
+	m.lock()
+	value, found := m.cache.get(key)
+	if found {
+		m.stats.Hits++
+	} else {
+		m.stats.Misses++
+	}
+	m.unlock()
+	if found {
+		return value
+	}
+
+	value = m.f(m.Call, key)
+
+	m.lock()
+	if m.cache.put(key, value) {
+		m.stats.Evictions++
+	}
+	m.unlock()
+	return value
+}
+
+// Stats returns the counts of cache hits, misses and evictions so far, along
+// with the number of results cached.
+func (m *Func[K, V]) Stats() Stats {
+	m.lock()
+	defer m.unlock()
+	stats := m.stats
+	stats.Size = m.cache.len()
+	return stats
+}
+
+// Reset empties the cache and zeroes the stats.
+func (m *Func[K, V]) Reset() {
+	m.lock()
+	defer m.unlock()
+	m.cache.clear()
+	m.stats = Stats{}
+}
+
+func (m *Func[K, V]) lock() {
+	if m.mu != nil {
+		m.mu.Lock()
+	}
+}
+
+func (m *Func[K, V]) unlock() {
+	if m.mu != nil {
+		m.mu.Unlock()
+	}
+}
+
+type cache[K comparable, V any] interface {
+	get(key K) (V, bool)
+	// put stores the value, reporting whether another was evicted for it
+	put(key K, value V) bool
+	len() int
+	clear()
+}
+
+type mapCache[K comparable, V any] map[K]V
+
+func (c mapCache[K, V]) get(key K) (V, bool) {
+	value, found := c[key]
+	return value, found
+}
+
+func (c mapCache[K, V]) put(key K, value V) bool {
+	c[key] = value
+	return false
+}
+
+func (c mapCache[K, V]) len() int {
+	return len(c)
+}
+
+func (c mapCache[K, V]) clear() {
+	clear(c)
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// lruCache keeps its entries in a list from the most recently used to the
+// least, so that the least recently used can be found to evict.
+type lruCache[K comparable, V any] struct {
+	capacity int
+	entries  map[K]*data.ListNode[lruEntry[K, V]]
+	order    *data.List[lruEntry[K, V]]
+}
+
+func newLRUCache[K comparable, V any](capacity int) *lruCache[K, V] {
+	return &lruCache[K, V]{
+		capacity: capacity,
+		entries:  make(map[K]*data.ListNode[lruEntry[K, V]], capacity),
+		order:    data.NewList[lruEntry[K, V]](),
+	}
+}
+
+func (c *lruCache[K, V]) get(key K) (value V, found bool) {
+	node, found := c.entries[key]
+	if !found {
+		return value, false
+	}
+	c.order.MoveToFront(node)
+	return node.Value.value, true
+}
+
+func (c *lruCache[K, V]) put(key K, value V) bool {
+	if node, found := c.entries[key]; found {
+		node.Value.value = value
+		c.order.MoveToFront(node)
+		return false
+	}
+
+	evicted := false
+	if c.order.Len() >= c.capacity {
+		oldest, _ := c.order.Remove(c.order.Back())
+		delete(c.entries, oldest.key)
+		evicted = true
+	}
+	c.entries[key] = c.order.PushFront(lruEntry[K, V]{key, value})
+	return evicted
+}
+
+func (c *lruCache[K, V]) len() int {
+	return c.order.Len()
+}
+
+func (c *lruCache[K, V]) clear() {
+	clear(c.entries)
+	c.order = data.NewList[lruEntry[K, V]]()
+}
@@ -0,0 +1,127 @@
+package memo
+
+import (
+	"sync"
+	"testing"
+)
+
+func fibonacci(recurse func(int) int, n int) int {
+	if n < 2 {
+		return n
+	}
+	return recurse(n-1) + recurse(n-2)
+}
+
+func TestNew_Recursive(t *testing.T) {
+	fib := New(fibonacci)
+	if got := fib.Call(90); got != 2880067194370816120 {
+		t.Errorf("Call(90) = %d, want 2880067194370816120", got)
+	}
+	// each number is worked out once, and looked up once more by the number
+	// two above it
+	want := Stats{Hits: 88, Misses: 91, Size: 91}
+	if got := fib.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	fib.Call(90)
+	if got := fib.Stats(); got.Hits != 89 || got.Misses != 91 {
+		t.Errorf("Stats() = %+v, want one more hit", got)
+	}
+
+	fib.Reset()
+	if got := fib.Stats(); got != (Stats{}) {
+		t.Errorf("Stats() after Reset() = %+v, want zero", got)
+	}
+}
+
+func TestOf_Key(t *testing.T) {
+	type key struct{ a, b int }
+	calls := 0
+	sum := Of(func(k key) int {
+		calls++
+		return k.a + k.b
+	})
+
+	for _, k := range []key{{1, 2}, {2, 1}, {1, 2}, {1, 2}} {
+		if got := sum.Call(k); got != 3 {
+			t.Errorf("Call(%v) = %d, want 3", k, got)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Function called %d times, want once for each different key", calls)
+	}
+}
+
+func TestWithCapacity(t *testing.T) {
+	calls := make(map[string]int)
+	upper := Of(func(s string) string {
+		calls[s]++
+		return s + "!"
+	}, WithCapacity(2))
+
+	// b is evicted when c is added, as a was used more recently
+	for _, s := range []string{"a", "b", "a", "c", "a", "b"} {
+		if got := upper.Call(s); got != s+"!" {
+			t.Errorf("Call(%q) = %q", s, got)
+		}
+	}
+
+	if calls["a"] != 1 || calls["b"] != 2 || calls["c"] != 1 {
+		t.Errorf("Function calls = %v, want a once, b twice and c once", calls)
+	}
+	want := Stats{Hits: 2, Misses: 4, Evictions: 2, Size: 2}
+	if got := upper.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestWithCapacity_Recursive(t *testing.T) {
+	fib := New(fibonacci, WithCapacity(3))
+	if got := fib.Call(60); got != 1548008755920 {
+		t.Errorf("Call(60) = %d, want 1548008755920", got)
+	}
+	if got := fib.Stats(); got.Size != 3 || got.Evictions == 0 {
+		t.Errorf("Stats() = %+v, want a full cache after evictions", got)
+	}
+}
+
+func TestConcurrent(t *testing.T) {
+	fib := New(fibonacci, Concurrent(), WithCapacity(50))
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range 70 {
+				if got, want := fib.Call(n), New(fibonacci).Call(n); got != want {
+					t.Errorf("goroutine %d: Call(%d) = %d, want %d", i, n, got, want)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := fib.Stats(); got.Hits+got.Misses < 20*70 {
+		t.Errorf("Stats() = %+v, want every call counted", got)
+	}
+}
+
+func BenchmarkMemo(b *testing.B) {
+	b.Run("unbounded", func(b *testing.B) {
+		for b.Loop() {
+			New(fibonacci).Call(90)
+		}
+	})
+	b.Run("lru", func(b *testing.B) {
+		for b.Loop() {
+			New(fibonacci, WithCapacity(16)).Call(90)
+		}
+	})
+	b.Run("concurrent", func(b *testing.B) {
+		for b.Loop() {
+			New(fibonacci, Concurrent()).Call(90)
+		}
+	})
+}
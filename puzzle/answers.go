@@ -0,0 +1,135 @@
+package puzzle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+)
+
+// Answers is the store of known correct answers, used to check that a change
+// to shared code has not changed the answer to an old puzzle. Answers are kept
+// for the puzzle input, and for the example inputs checked in with each day.
+type Answers struct {
+	answers map[answerKey]string
+}
+
+// answerKey identifies an answer, where the input is the path of an example
+// relative to the day's directory, or empty for the puzzle input.
+type answerKey struct {
+	year, day, part int
+	input           string
+}
+
+// Example is the known answer to one part of a puzzle for an example input.
+type Example struct {
+	Input  string
+	Part   int
+	Answer string
+}
+
+// answerRecord is the form in which each answer is saved to the answers file.
+type answerRecord struct {
+	Year   int    `json:"year"`
+	Day    int    `json:"day"`
+	Part   int    `json:"part"`
+	Input  string `json:"input,omitempty"`
+	Answer string `json:"answer"`
+}
+
+// NewAnswers returns an empty answer store.
+func NewAnswers() *Answers {
+	return &Answers{answers: make(map[answerKey]string)}
+}
+
+// ReadAnswers loads the answer store from a file. A file that does not exist
+// yet is treated as an empty store.
+func ReadAnswers(path string) (*Answers, error) {
+	a := NewAnswers()
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records []answerRecord
+	if err := json.Unmarshal(b, &records); err != nil {
+		return nil, fmt.Errorf("reading answers from %s: %w", path, err)
+	}
+	for _, r := range records {
+		a.answers[answerKey{r.Year, r.Day, r.Part, r.Input}] = r.Answer
+	}
+	return a, nil
+}
+
+// WriteFile saves the answer store to a file, ordered by year, day, input and
+// part so that changes are easy to review.
+func (a *Answers) WriteFile(path string) error {
+	records := make([]answerRecord, 0, len(a.answers))
+	for k, answer := range a.answers {
+		records = append(records, answerRecord{Year: k.year, Day: k.day, Part: k.part, Input: k.input, Answer: answer})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Year != records[j].Year {
+			return records[i].Year < records[j].Year
+		}
+		if records[i].Day != records[j].Day {
+			return records[i].Day < records[j].Day
+		}
+		if records[i].Input != records[j].Input {
+			return records[i].Input < records[j].Input
+		}
+		return records[i].Part < records[j].Part
+	})
+
+	b, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+// Get returns the known answer for a part of a puzzle.
+func (a *Answers) Get(year, day, part int) (string, bool) {
+	answer, found := a.answers[answerKey{year, day, part, ""}]
+	return answer, found
+}
+
+// Set records the known answer for a part of a puzzle.
+func (a *Answers) Set(year, day, part int, answer string) {
+	a.answers[answerKey{year, day, part, ""}] = answer
+}
+
+// SetExample records the known answer for a part of a puzzle, for the example
+// input at a path relative to the day's directory, e.g. testdata/example.txt.
+func (a *Answers) SetExample(year, day, part int, input, answer string) {
+	a.answers[answerKey{year, day, part, input}] = answer
+}
+
+// Examples returns the known answers for the examples of a puzzle, ordered by
+// input and part.
+func (a *Answers) Examples(year, day int) []Example {
+	var examples []Example
+	for k, answer := range a.answers {
+		if k.year == year && k.day == day && k.input != "" {
+			examples = append(examples, Example{Input: k.input, Part: k.part, Answer: answer})
+		}
+	}
+	sort.Slice(examples, func(i, j int) bool {
+		if examples[i].Input != examples[j].Input {
+			return examples[i].Input < examples[j].Input
+		}
+		return examples[i].Part < examples[j].Part
+	})
+	return examples
+}
+
+// FormatAnswer returns the form in which an answer is stored and compared.
+func FormatAnswer(answer Answer) string {
+	return fmt.Sprint(answer)
+}
@@ -0,0 +1,85 @@
+package puzzle
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadAnswers_MissingFileIsEmpty(t *testing.T) {
+	a, err := ReadAnswers(filepath.Join(t.TempDir(), "answers.json"))
+	if err != nil {
+		t.Fatalf("ReadAnswers() error = %v", err)
+	}
+	if _, found := a.Get(2024, 7, 1); found {
+		t.Error("Expected no answers in an empty store")
+	}
+}
+
+func TestAnswers_WriteFileThenRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+
+	a := NewAnswers()
+	a.Set(2024, 7, 2, "11387")
+	a.Set(2024, 7, 1, FormatAnswer(uint64(3749)))
+	a.Set(2021, 13, 2, "#..#\n#..#")
+	a.SetExample(2024, 7, 1, "testdata/example.txt", "3749")
+	if err := a.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	got, err := ReadAnswers(path)
+	if err != nil {
+		t.Fatalf("ReadAnswers() error = %v", err)
+	}
+
+	tests := []struct {
+		year, day, part int
+		want            string
+	}{
+		{2024, 7, 1, "3749"},
+		{2024, 7, 2, "11387"},
+		{2021, 13, 2, "#..#\n#..#"},
+	}
+	for _, tt := range tests {
+		if answer, found := got.Get(tt.year, tt.day, tt.part); !found || answer != tt.want {
+			t.Errorf("Get(%d, %d, %d) = %q, %v, want %q", tt.year, tt.day, tt.part, answer, found, tt.want)
+		}
+	}
+	if examples := got.Examples(2024, 7); !reflect.DeepEqual(examples, []Example{{"testdata/example.txt", 1, "3749"}}) {
+		t.Errorf("Examples(2024, 7) = %v", examples)
+	}
+}
+
+func TestAnswers_Examples(t *testing.T) {
+	a := NewAnswers()
+	a.Set(2023, 1, 1, "54331")
+	a.SetExample(2023, 1, 2, "testdata/example2.txt", "281")
+	a.SetExample(2023, 1, 2, "testdata/example.txt", "142")
+	a.SetExample(2023, 1, 1, "testdata/example.txt", "142")
+	a.SetExample(2023, 2, 1, "testdata/example.txt", "8")
+
+	want := []Example{
+		{Input: "testdata/example.txt", Part: 1, Answer: "142"},
+		{Input: "testdata/example.txt", Part: 2, Answer: "142"},
+		{Input: "testdata/example2.txt", Part: 2, Answer: "281"},
+	}
+	if got := a.Examples(2023, 1); !reflect.DeepEqual(got, want) {
+		t.Errorf("Examples() = %v, want %v", got, want)
+	}
+	if answer, _ := a.Get(2023, 1, 1); answer != "54331" {
+		t.Errorf("Get() = %q, want the puzzle input's answer", answer)
+	}
+}
+
+func TestReadAnswers_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	if err := os.WriteFile(path, []byte("2024 7 1 3749"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadAnswers(path); err == nil {
+		t.Error("Expected an error reading an invalid answers file")
+	}
+}
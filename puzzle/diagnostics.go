@@ -0,0 +1,17 @@
+package puzzle
+
+// Diagnoser is implemented by solvers that report more about a run than the
+// answers, such as counts of intermediate results, for tools to show alongside
+// them. Diagnostics is called after each part is solved.
+type Diagnoser interface {
+	Diagnostics() map[string]any
+}
+
+// Diagnostics returns what the solver reports about the parts solved so far,
+// or nil when it reports nothing.
+func Diagnostics(s Solver) map[string]any {
+	if d, ok := s.(Diagnoser); ok {
+		return d.Diagnostics()
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package puzzle
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownParam is returned when setting a parameter the solver does not
+// declare.
+var ErrUnknownParam = errors.New("puzzle: unknown parameter")
+
+// ErrInvalidParam is returned when the parameters are set to values the solver
+// cannot work with.
+var ErrInvalidParam = errors.New("puzzle: invalid parameter")
+
+// Configurable is implemented by solvers that take parameters, such as a number
+// of steps to simulate, where the examples use different values from the real
+// input. Params declares each parameter on the flag set, bound to a field of
+// the solver, with its default value and help text, e.g.
+//
+//	params.IntVar(&s.steps, "steps", 100, "number of steps to simulate")
+type Configurable interface {
+	Params(params *flag.FlagSet)
+}
+
+// ParamValidator is implemented by configurable solvers whose parameters have
+// a range of valid values, such as a window size that must be at least 1.
+// ValidateParams is called by SetParams once all the values are set.
+type ParamValidator interface {
+	ValidateParams() error
+}
+
+// Params returns the parameters declared by the solver, set to their defaults,
+// or nil when the solver takes no parameters.
+func Params(s Solver) *flag.FlagSet {
+	c, ok := s.(Configurable)
+	if !ok {
+		return nil
+	}
+	params := flag.NewFlagSet("params", flag.ContinueOnError)
+	params.SetOutput(io.Discard)
+	c.Params(params)
+	return params
+}
+
+// SetParams sets the solver's parameters from their names and values, before
+// the input is parsed. Parameters that are not given keep their defaults. The
+// values are then checked by the solver, if it is a ParamValidator.
+func SetParams(s Solver, values map[string]string) error {
+	params := Params(s)
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		if params == nil {
+			return fmt.Errorf("%w %q, the solver takes no parameters", ErrUnknownParam, name)
+		}
+		param := params.Lookup(name)
+		if param == nil {
+			return fmt.Errorf("%w %q, expected one of: %s", ErrUnknownParam, name, strings.Join(ParamNames(params), ", "))
+		}
+		if err := params.Set(name, values[name]); err != nil {
+			return fmt.Errorf("invalid value %q for parameter %s (%s): %w", values[name], name, param.Usage, err)
+		}
+	}
+	if v, ok := s.(ParamValidator); ok {
+		if err := v.ValidateParams(); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidParam, err)
+		}
+	}
+	return nil
+}
+
+// ParamNames returns the names of the parameters in the flag set, in order.
+func ParamNames(params *flag.FlagSet) []string {
+	var names []string
+	params.VisitAll(func(f *flag.Flag) {
+		names = append(names, f.Name)
+	})
+	return names
+}
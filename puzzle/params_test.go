@@ -0,0 +1,86 @@
+package puzzle
+
+import (
+	"errors"
+	"flag"
+	"strings"
+	"testing"
+)
+
+// repeater repeats its input a number of times, which is a parameter.
+type repeater struct {
+	wordCounter
+	times     int
+	separator string
+}
+
+func (r *repeater) Params(params *flag.FlagSet) {
+	params.IntVar(&r.times, "times", 2, "number of times to repeat the input")
+	params.StringVar(&r.separator, "separator", " ", "text between the repeats")
+}
+
+func (r *repeater) ValidateParams() error {
+	if r.times < 0 {
+		return errors.New("times must not be negative")
+	}
+	return nil
+}
+
+func (r *repeater) Part1() (Answer, error) {
+	return strings.Repeat(r.text+r.separator, r.times), nil
+}
+
+func TestSetParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		solver  Solver
+		values  map[string]string
+		want    string
+		wantErr error
+	}{
+		{name: "defaults", solver: &repeater{}, want: "a a "},
+		{name: "set", solver: &repeater{}, values: map[string]string{"times": "3", "separator": "-"}, want: "a-a-a-"},
+		{name: "unknown", solver: &repeater{}, values: map[string]string{"count": "3"}, wantErr: ErrUnknownParam},
+		{name: "invalid", solver: &repeater{}, values: map[string]string{"times": "three"}},
+		{name: "out of range", solver: &repeater{}, values: map[string]string{"times": "-1"}, wantErr: ErrInvalidParam},
+		{name: "no params", solver: &wordCounter{}, values: map[string]string{"times": "3"}, wantErr: ErrUnknownParam},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Params(tt.solver)
+			err := SetParams(tt.solver, tt.values)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("SetParams() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetParams() error = %v", err)
+			}
+			_ = tt.solver.Parse(strings.NewReader("a"))
+			if got, _ := tt.solver.Part1(); got != tt.want {
+				t.Errorf("Part1() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetParams_ErrorNamesExpectedParams(t *testing.T) {
+	err := SetParams(&repeater{}, map[string]string{"count": "3"})
+	if err == nil || !strings.Contains(err.Error(), "separator, times") {
+		t.Errorf("SetParams() error = %v, want it to name separator and times", err)
+	}
+}
+
+func TestRegister_NewSolverHasDefaultParams(t *testing.T) {
+	Register(1918, 1, func() Solver { return &repeater{} })
+	p, _ := Lookup(1918, 1)
+
+	if r := p.New().(*repeater); r.times != 2 || r.separator != " " {
+		t.Errorf("New() = %+v, want the default parameters", r)
+	}
+}
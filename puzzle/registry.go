@@ -0,0 +1,113 @@
+// Package puzzle holds the registry of Advent of Code solutions, so that any
+// year and day can be run from a single command, or called as code from tools
+// and tests, instead of from its own directory.
+package puzzle
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Answer is the result of solving one part of a puzzle. Solvers return the
+// natural type of their answer, such as an int, uint64 or string.
+type Answer = any
+
+var (
+	// ErrNotImplemented is returned by a Solver for a part that has not been solved.
+	ErrNotImplemented = errors.New("puzzle: part not implemented")
+
+	// ErrNoSuchPart is returned when asked to solve a part other than 1 or 2.
+	ErrNoSuchPart = errors.New("puzzle: no such part")
+)
+
+// Solver solves both parts of a single day's puzzle. Parse is called once with
+// the puzzle input, after which the parts may be called in any order, and more
+// than once.
+type Solver interface {
+	Parse(input io.Reader) error
+	Part1() (Answer, error)
+	Part2() (Answer, error)
+}
+
+// NewSolver creates a Solver that is ready to parse its puzzle input.
+type NewSolver func() Solver
+
+// Puzzle is the solution for a single year and day.
+type Puzzle struct {
+	Year int
+	Day  int
+	New  NewSolver
+}
+
+type key struct {
+	year, day int
+}
+
+var registry = make(map[key]Puzzle)
+
+// Register adds the solution for the given year and day to the registry. It is
+// intended to be called from the init function of each day's package.
+//
+// Register panics if a solution is already registered for the year and day.
+func Register(year, day int, newSolver NewSolver) {
+	k := key{year: year, day: day}
+	if _, exists := registry[k]; exists {
+		panic(fmt.Sprintf("puzzle: %d day %d registered twice", year, day))
+	}
+	registry[k] = Puzzle{Year: year, Day: day, New: withDefaultParams(newSolver)}
+}
+
+// withDefaultParams sets the parameters of each new solver to their defaults,
+// so that a solver is ready to use whether or not its parameters are set.
+func withDefaultParams(newSolver NewSolver) NewSolver {
+	return func() Solver {
+		s := newSolver()
+		Params(s)
+		return s
+	}
+}
+
+// Lookup returns the solution registered for the given year and day.
+func Lookup(year, day int) (Puzzle, bool) {
+	p, found := registry[key{year: year, day: day}]
+	return p, found
+}
+
+// All returns every registered solution, ordered by year and then day.
+func All() []Puzzle {
+	result := make([]Puzzle, 0, len(registry))
+	for _, p := range registry {
+		result = append(result, p)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Year != result[j].Year {
+			return result[i].Year < result[j].Year
+		}
+		return result[i].Day < result[j].Day
+	})
+	return result
+}
+
+// SolvePart returns the answer for part 1 or 2 of a solver that has already
+// parsed its input.
+func SolvePart(s Solver, part int) (Answer, error) {
+	switch part {
+	case 1:
+		return s.Part1()
+	case 2:
+		return s.Part2()
+	}
+	return nil, fmt.Errorf("%w %d, expected 1 or 2", ErrNoSuchPart, part)
+}
+
+// Solve parses the input with a new solver for the puzzle, and returns the
+// answer for the given part.
+func (p Puzzle) Solve(part int, input io.Reader) (Answer, error) {
+	s := p.New()
+	if err := s.Parse(input); err != nil {
+		return nil, fmt.Errorf("parsing %d day %d: %w", p.Year, p.Day, err)
+	}
+	return SolvePart(s, part)
+}
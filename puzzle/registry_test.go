@@ -0,0 +1,98 @@
+package puzzle
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// wordCounter counts the words in its input for part 1, and has no part 2 yet.
+type wordCounter struct {
+	text string
+}
+
+func (w *wordCounter) Parse(input io.Reader) error {
+	b, err := io.ReadAll(input)
+	w.text = string(b)
+	return err
+}
+
+func (w *wordCounter) Part1() (Answer, error) {
+	return len(strings.Fields(w.text)), nil
+}
+
+func (w *wordCounter) Part2() (Answer, error) {
+	return nil, ErrNotImplemented
+}
+
+func newWordCounter() Solver {
+	return &wordCounter{}
+}
+
+func TestRegister_Lookup(t *testing.T) {
+	Register(1915, 2, newWordCounter)
+
+	p, found := Lookup(1915, 2)
+	if !found {
+		t.Fatal("Expected 1915 day 2 to be registered")
+	}
+	if p.Year != 1915 || p.Day != 2 || p.New == nil {
+		t.Errorf("Lookup() = %+v, want year 1915, day 2 with a solver", p)
+	}
+
+	if _, found := Lookup(1915, 3); found {
+		t.Error("Expected 1915 day 3 not to be registered")
+	}
+}
+
+func TestRegister_PanicsWhenRegisteredTwice(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("Register did not panic")
+		}
+	}()
+
+	Register(1916, 1, newWordCounter)
+	Register(1916, 1, newWordCounter)
+}
+
+func TestAll_OrderedByYearThenDay(t *testing.T) {
+	Register(1917, 10, newWordCounter)
+	Register(1917, 9, newWordCounter)
+	Register(1914, 25, newWordCounter)
+
+	var previous Puzzle
+	for i, p := range All() {
+		if i > 0 && (p.Year < previous.Year || p.Year == previous.Year && p.Day < previous.Day) {
+			t.Errorf("%d day %d listed after %d day %d", p.Year, p.Day, previous.Year, previous.Day)
+		}
+		previous = p
+	}
+}
+
+func TestPuzzle_Solve(t *testing.T) {
+	p := Puzzle{Year: 1918, Day: 1, New: newWordCounter}
+
+	tests := []struct {
+		name    string
+		part    int
+		want    Answer
+		wantErr error
+	}{
+		{name: "part 1", part: 1, want: 3},
+		{name: "part 2 not implemented", part: 2, wantErr: ErrNotImplemented},
+		{name: "no part 3", part: 3, wantErr: ErrNoSuchPart},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.Solve(tt.part, strings.NewReader("one two\nthree"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Solve() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Solve() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,18 +0,0 @@
-package main
-
-import (
-	"log"
-	"os"
-)
-
-func main() {
-	file, err := os.Open(os.Args[1])
-	if err != nil {
-		log.Fatalf("Error opening file: %s", os.Args[1])
-	}
-
-	defer func(file *os.File) {
-		_ = file.Close()
-	}(file)
-
-}